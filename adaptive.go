@@ -0,0 +1,95 @@
+package unicap
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// adaptiveJitter is the fraction by which adaptive poll delays are
+	// randomly lengthened or shortened.
+	adaptiveJitter = 0.2
+
+	// minAdaptiveInterval is the shortest interval adaptive polling uses
+	// between checks.
+	minAdaptiveInterval = 250 * time.Millisecond
+)
+
+// WithAdaptivePolling makes the poller learn from the time tasks take to
+// become ready, recorded in times per provider and task type. Once enough
+// solves of a type have been seen, the first check is made near the 25th
+// percentile of recent solve times and checks are made every quarter of the
+// interquartile range until the 75th percentile, so they cluster around the
+// median; later checks back off as usual. Every delay is jittered so tasks
+// created together do not poll in lockstep. Until enough solves are recorded,
+// the poller's configuration and profiles apply unchanged. times may be shared
+// by several pollers.
+func WithAdaptivePolling(times *SolveTimes) PollerOption {
+	return func(p *Poller) {
+		if times != nil {
+			p.times = times
+			p.adaptive = true
+		}
+	}
+}
+
+// WithSolveTimes makes the poller record the time tasks take to become ready
+// into times, per provider and task type, without changing when it checks.
+// Client.SolveAt reads lead times from them. times may be shared by several
+// pollers.
+func WithSolveTimes(times *SolveTimes) PollerOption {
+	return func(p *Poller) {
+		if times != nil {
+			p.times = times
+		}
+	}
+}
+
+// solveWindow is the learned span in which tasks of a type usually become
+// ready.
+type solveWindow struct {
+	p25 time.Duration
+	p75 time.Duration
+}
+
+// learned returns the solve window for the task type once enough solves have
+// been recorded.
+func (p *Poller) learned(taskType TaskType) (solveWindow, bool) {
+	if !p.adaptive {
+		return solveWindow{}, false
+	}
+
+	p25, ok := p.times.Quantile(p.provider.Name(), taskType, 0.25)
+	if !ok {
+		return solveWindow{}, false
+	}
+
+	p75, _ := p.times.Quantile(p.provider.Name(), taskType, 0.75)
+
+	return solveWindow{p25: p25, p75: p75}, true
+}
+
+// backoff sets the delay before the next check of a task that is still
+// processing.
+func (p *Poller) backoff(state *pollState) {
+	w, ok := p.learned(state.taskType)
+	if !ok {
+		state.interval = nextInterval(state.interval, state.config)
+		state.next = state.interval
+
+		return
+	}
+
+	if time.Since(state.start) < w.p75 {
+		state.interval = min(max((w.p75-w.p25)/4, minAdaptiveInterval), state.config.MaxInterval)
+	} else {
+		state.interval = nextInterval(state.interval, state.config)
+	}
+
+	state.next = jitter(state.interval)
+}
+
+// jitter randomly lengthens or shortens d by up to adaptiveJitter.
+func jitter(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (1 + adaptiveJitter*(2*rand.Float64()-1)))
+}
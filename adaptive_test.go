@@ -0,0 +1,99 @@
+package unicap
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAdaptivePollingRecordsSolveTimes(t *testing.T) {
+	times := NewSolveTimes(0)
+	provider := &fakeProvider{steps: []step{{result: processing()}, {result: ready()}}}
+	poller := NewPoller(provider, testConfig(), WithAdaptivePolling(times))
+
+	for range minSolveSamples {
+		provider.calls = 0
+
+		if _, err := poller.PollTask(context.Background(), "task-1", TaskTypeText); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, ok := times.Quantile("fake", TaskTypeText, 0.5); !ok {
+		t.Error("Quantile() ok = false after polling, want true")
+	}
+}
+
+func TestAdaptivePollingSchedule(t *testing.T) {
+	times := NewSolveTimes(0)
+	for i := range 20 {
+		times.Record("fake", TaskTypeText, 10*time.Second+time.Duration(i)*time.Second)
+	}
+
+	config := DefaultPollerConfig()
+	poller := NewPoller(&fakeProvider{}, config, WithAdaptivePolling(times))
+
+	p25, _ := times.Quantile("fake", TaskTypeText, 0.25)
+	p75, _ := times.Quantile("fake", TaskTypeText, 0.75)
+
+	state := poller.newPollState(TaskTypeText)
+	if lo, hi := scale(p25, 1-adaptiveJitter), scale(p25, 1+adaptiveJitter); state.next < lo || state.next > hi {
+		t.Errorf("first check after %v, want within [%v, %v]", state.next, lo, hi)
+	}
+
+	dense := (p75 - p25) / 4
+	poller.backoff(&state)
+	if state.interval != dense {
+		t.Errorf("interval inside window = %v, want %v", state.interval, dense)
+	}
+
+	state.start = time.Now().Add(-p75)
+	poller.backoff(&state)
+	if want := nextInterval(dense, config); state.interval != want {
+		t.Errorf("interval after window = %v, want %v", state.interval, want)
+	}
+
+	untrained := poller.newPollState(TaskTypeImageToText)
+	if untrained.next != config.InitialDelay {
+		t.Errorf("first check for unseen type after %v, want %v", untrained.next, config.InitialDelay)
+	}
+}
+
+func TestSolveTimesRecordWithoutAdapting(t *testing.T) {
+	times := NewSolveTimes(0)
+	for i := range 20 {
+		times.Record("fake", TaskTypeText, 10*time.Second+time.Duration(i)*time.Second)
+	}
+
+	config := DefaultPollerConfig()
+	poller := NewPoller(&fakeProvider{}, config, WithSolveTimes(times))
+
+	if state := poller.newPollState(TaskTypeText); state.next != config.InitialDelay {
+		t.Errorf("first check after %v, want %v", state.next, config.InitialDelay)
+	}
+}
+
+func TestClientDefaultPollerRecordsSolveTimes(t *testing.T) {
+	provider := &fakeProvider{}
+
+	client, err := New(provider)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if client.poller.times == nil {
+		t.Fatal("default poller records no solve times")
+	}
+
+	if client.poller.adaptive {
+		t.Error("default poller adapts its schedule, want configured schedule")
+	}
+
+	if shared := client.pollerFor(&fakeProvider{}); shared.times != client.poller.times || shared.adaptive {
+		t.Error("pollerFor does not share solve times without adapting")
+	}
+}
+
+func scale(d time.Duration, f float64) time.Duration {
+	return time.Duration(float64(d) * f)
+}
@@ -0,0 +1,84 @@
+package unicap
+
+import (
+	"context"
+	"sync"
+)
+
+// SolveHandle tracks a solve started by SolveAsync. Its methods are safe for
+// concurrent use.
+type SolveHandle struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	taskID   string
+	solution *Solution
+	err      error
+}
+
+// SolveAsync starts solving a task in the background and returns immediately.
+// The solve behaves exactly like Solve, including hedging, in-flight limits,
+// and polling backoff, and ends when ctx is done or the handle is cancelled.
+func (c *Client) SolveAsync(ctx context.Context, task Task) *SolveHandle {
+	ctx, cancel := context.WithCancel(ctx)
+
+	h := &SolveHandle{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer cancel()
+
+		solution, err := c.solve(ctx, task, h.setTaskID)
+
+		h.mu.Lock()
+		h.solution, h.err = solution, err
+		h.mu.Unlock()
+
+		close(h.done)
+	}()
+
+	return h
+}
+
+// Done returns a channel that is closed once the solve has finished.
+func (h *SolveHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Result blocks until the solve has finished and returns its outcome.
+func (h *SolveHandle) Result() (*Solution, error) {
+	<-h.done
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.solution, h.err
+}
+
+// TaskID returns the provider task ID, or the empty string until the task has
+// been created. When the solve is hedged it is the ID of the first task
+// created.
+func (h *SolveHandle) TaskID() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.taskID
+}
+
+// Cancel stops the solve. Result then returns the context error unless the
+// solve had already finished.
+func (h *SolveHandle) Cancel() {
+	h.cancel()
+}
+
+func (h *SolveHandle) setTaskID(taskID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.taskID == "" {
+		h.taskID = taskID
+	}
+}
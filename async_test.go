@@ -0,0 +1,65 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSolveAsync(t *testing.T) {
+	provider := &fakeProvider{steps: []step{{result: processing()}, {result: ready()}}}
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	h := client.SolveAsync(t.Context(), textTask{})
+	<-h.Done()
+
+	solution, err := h.Result()
+	if err != nil {
+		t.Fatalf("Result: %v", err)
+	}
+
+	if solution.Token != "solved" {
+		t.Errorf("Token = %q, want %q", solution.Token, "solved")
+	}
+
+	if got := h.TaskID(); got != "task-1" {
+		t.Errorf("TaskID() = %q, want %q", got, "task-1")
+	}
+}
+
+func TestSolveAsyncCancel(t *testing.T) {
+	provider := &fakeProvider{steps: []step{{result: processing()}}}
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	h := client.SolveAsync(t.Context(), textTask{})
+	h.Cancel()
+
+	if _, err := h.Result(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Result() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestSolveAsyncInvalidTask(t *testing.T) {
+	client, err := New(&fakeProvider{steps: []step{{result: ready()}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	h := client.SolveAsync(t.Context(), nil)
+
+	if _, err := h.Result(); !errors.Is(err, ErrInvalidTask) {
+		t.Errorf("errors.Is(err, ErrInvalidTask) = false, want true (err = %v)", err)
+	}
+
+	if got := h.TaskID(); got != "" {
+		t.Errorf("TaskID() = %q, want empty", got)
+	}
+}
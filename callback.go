@@ -0,0 +1,262 @@
+package unicap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCallbackBody bounds the callback request body read by a
+	// CallbackReceiver.
+	maxCallbackBody = 1 << 20
+
+	// callbackRetention is how long a CallbackReceiver remembers a callback
+	// that no solve is waiting for, covering callbacks that arrive before the
+	// solve starts waiting.
+	callbackRetention = 10 * time.Minute
+)
+
+type callbackURLKey struct{}
+
+// callback holds the callback mode configured by WithCallback.
+type callback struct {
+	receiver *CallbackReceiver
+	url      string
+	grace    time.Duration
+}
+
+// WithCallbackURL returns a context that asks providers to notify url when a
+// task created with it is done. Built-in providers send it as callbackUrl on
+// createTask.
+func WithCallbackURL(ctx context.Context, callbackURL string) context.Context {
+	return context.WithValue(ctx, callbackURLKey{}, callbackURL)
+}
+
+// CallbackURL returns the callback URL carried by ctx, or the empty string.
+func CallbackURL(ctx context.Context) string {
+	callbackURL, _ := ctx.Value(callbackURLKey{}).(string)
+
+	return callbackURL
+}
+
+// CallbackReceiver is an http.Handler that accepts task completion callbacks
+// from providers and wakes the solves waiting for them. It understands JSON
+// bodies carrying taskId or id, as sent by CapSolver and Anti-Captcha, and
+// form or query parameters id or taskId, as sent by 2Captcha. A callback is
+// only a signal: the result itself is always fetched from the provider, so a
+// forged callback cannot inject a solution. It is safe for concurrent use.
+type CallbackReceiver struct {
+	mu      sync.Mutex
+	signals map[string]*callbackSignal
+	swept   time.Time
+}
+
+// callbackSignal is closed when the callback for a task arrives.
+type callbackSignal struct {
+	ch       chan struct{}
+	fired    bool
+	received time.Time
+}
+
+// NewCallbackReceiver creates a CallbackReceiver.
+func NewCallbackReceiver() *CallbackReceiver {
+	return &CallbackReceiver{signals: make(map[string]*callbackSignal)}
+}
+
+// ServeHTTP records the callback for the task named in the request.
+func (r *CallbackReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	taskID, err := callbackTaskID(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.signalLocked(taskID)
+	if !s.fired {
+		s.fired = true
+		s.received = time.Now()
+		close(s.ch)
+	}
+
+	if time.Since(r.swept) > time.Minute {
+		r.sweepLocked()
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// watch returns a channel that is closed once the callback for taskID
+// arrives, including one that arrived before the call.
+func (r *CallbackReceiver) watch(taskID string) <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.signalLocked(taskID).ch
+}
+
+// forget drops the state kept for taskID.
+func (r *CallbackReceiver) forget(taskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.signals, taskID)
+}
+
+func (r *CallbackReceiver) signalLocked(taskID string) *callbackSignal {
+	s, ok := r.signals[taskID]
+	if !ok {
+		s = &callbackSignal{ch: make(chan struct{})}
+		r.signals[taskID] = s
+	}
+
+	return s
+}
+
+// sweepLocked drops callbacks no solve has claimed within callbackRetention.
+func (r *CallbackReceiver) sweepLocked() {
+	for id, s := range r.signals {
+		if s.fired && time.Since(s.received) > callbackRetention {
+			delete(r.signals, id)
+		}
+	}
+
+	r.swept = time.Now()
+}
+
+// errNoCallbackTaskID reports a callback that does not name a task.
+var errNoCallbackTaskID = errors.New("callback has no task ID")
+
+// callbackTaskID extracts the task ID from a callback request.
+func callbackTaskID(req *http.Request) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxCallbackBody))
+	if err != nil {
+		return "", fmt.Errorf("reading callback: %w", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+	if mediaType == "application/json" {
+		var payload struct {
+			TaskID json.RawMessage `json:"taskId"`
+			ID     json.RawMessage `json:"id"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return "", fmt.Errorf("decoding callback: %w", err)
+		}
+
+		for _, raw := range []json.RawMessage{payload.TaskID, payload.ID} {
+			if id := rawTaskID(raw); id != "" {
+				return id, nil
+			}
+		}
+
+		return "", errNoCallbackTaskID
+	}
+
+	values := req.URL.Query()
+	if mediaType == "application/x-www-form-urlencoded" {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", fmt.Errorf("decoding callback: %w", err)
+		}
+
+		for key, v := range form {
+			values[key] = append(values[key], v...)
+		}
+	}
+
+	for _, key := range []string{"id", "taskId"} {
+		if id := values.Get(key); id != "" {
+			return id, nil
+		}
+	}
+
+	return "", errNoCallbackTaskID
+}
+
+// rawTaskID returns a JSON number or string task ID as a string.
+func rawTaskID(raw json.RawMessage) string {
+	var id json.Number
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id.String()
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	return ""
+}
+
+// waitCallback waits up to grace for the callback of a created task and then
+// fetches its result once. It reports false when no callback arrived in time
+// or the fetched task is still processing, in which case the caller polls.
+// Behind a Delegator the callback names the backend's task ID, so that is the
+// ID it waits for.
+func (c *Client) waitCallback(ctx context.Context, provider Provider, taskID string) (bool, *TaskResult, error) {
+	_, callbackID := backendTask(provider, taskID)
+
+	signal := c.callback.receiver.watch(callbackID)
+	defer c.callback.receiver.forget(callbackID)
+
+	timer := time.NewTimer(c.callback.grace)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return true, nil, pollError(ctx, taskID)
+	case <-timer.C:
+		c.logger.InfoContext(ctx, "no callback received, falling back to polling",
+			slog.String("task_id", taskID),
+			slog.String("provider", provider.Name()),
+		)
+
+		return false, nil, nil
+	case <-signal:
+	}
+
+	result, err := provider.GetTaskResult(ctx, taskID)
+	if err != nil {
+		c.logger.WarnContext(ctx, "fetching result after callback failed, falling back to polling",
+			slog.String("task_id", taskID),
+			slog.Any("error", err),
+		)
+
+		return false, nil, nil
+	}
+
+	switch result.Status {
+	case TaskStatusReady:
+		c.logger.InfoContext(ctx, "task completed",
+			slog.String("task_id", taskID),
+			slog.String("provider", provider.Name()),
+			slog.Bool("callback", true),
+		)
+
+		result.Solution.Polls = 1
+
+		return true, result, nil
+	case TaskStatusFailed:
+		if result.Error != nil {
+			return true, nil, result.Error
+		}
+
+		return true, nil, fmt.Errorf("task %s: %w", taskID, ErrInvalidTask)
+	}
+
+	return false, nil, nil
+}
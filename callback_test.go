@@ -0,0 +1,176 @@
+package unicap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// callbackProvider is solved after delay and, when the task was created with
+// a callback URL and push is set, posts a JSON callback for it.
+type callbackProvider struct {
+	delay time.Duration
+	push  bool
+
+	created time.Time
+	polls   atomic.Int32
+}
+
+func (p *callbackProvider) CreateTask(ctx context.Context, _ Task) (string, error) {
+	p.created = time.Now()
+
+	if url := CallbackURL(ctx); url != "" && p.push {
+		go func() {
+			time.Sleep(p.delay)
+
+			resp, err := http.Post(url, "application/json", strings.NewReader(`{"errorId":0,"taskId":42,"status":"ready"}`))
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+
+	return "42", nil
+}
+
+func (p *callbackProvider) GetTaskResult(context.Context, string) (*TaskResult, error) {
+	p.polls.Add(1)
+
+	if time.Since(p.created) < p.delay {
+		return processing(), nil
+	}
+
+	return ready(), nil
+}
+
+func (p *callbackProvider) Name() string {
+	return "callback"
+}
+
+func TestSolveWithCallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		push      bool
+		wantPolls int32
+	}{
+		{name: "callback delivered", push: true, wantPolls: 1},
+		{name: "falls back to polling", push: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := NewCallbackReceiver()
+			server := httptest.NewServer(receiver)
+			t.Cleanup(server.Close)
+
+			provider := &callbackProvider{delay: 20 * time.Millisecond, push: tt.push}
+
+			client, err := New(provider,
+				WithPoller(NewPoller(provider, testConfig())),
+				WithCallback(receiver, server.URL, 50*time.Millisecond),
+			)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			solution, err := client.Solve(t.Context(), textTask{})
+			if err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+
+			if solution.Token != "solved" {
+				t.Errorf("Token = %q, want %q", solution.Token, "solved")
+			}
+
+			polls := provider.polls.Load()
+			if tt.wantPolls > 0 && polls != tt.wantPolls {
+				t.Errorf("GetTaskResult calls = %d, want %d", polls, tt.wantPolls)
+			}
+
+			if tt.wantPolls == 0 && polls == 0 {
+				t.Error("GetTaskResult never called, want polling fallback")
+			}
+		})
+	}
+}
+
+func TestCallbackReceiver(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		wantStatus  int
+		wantTaskID  string
+	}{
+		{
+			name:        "json numeric taskId",
+			target:      "/",
+			contentType: "application/json",
+			body:        `{"taskId":123,"status":"ready"}`,
+			wantStatus:  http.StatusOK,
+			wantTaskID:  "123",
+		},
+		{
+			name:        "json string id",
+			target:      "/",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"id":"abc-def"}`,
+			wantStatus:  http.StatusOK,
+			wantTaskID:  "abc-def",
+		},
+		{
+			name:        "form id",
+			target:      "/",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "id=456&code=answer",
+			wantStatus:  http.StatusOK,
+			wantTaskID:  "456",
+		},
+		{
+			name:       "query id",
+			target:     "/?id=789&code=answer",
+			wantStatus: http.StatusOK,
+			wantTaskID: "789",
+		},
+		{
+			name:        "missing task ID",
+			target:      "/",
+			contentType: "application/json",
+			body:        `{"status":"ready"}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := NewCallbackReceiver()
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			rec := httptest.NewRecorder()
+			receiver.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantTaskID == "" {
+				return
+			}
+
+			select {
+			case <-receiver.watch(tt.wantTaskID):
+			default:
+				t.Errorf("callback for %q not recorded", tt.wantTaskID)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"time"
 )
 
 // Client submits captcha tasks to a provider and retrieves their solutions.
@@ -14,6 +15,9 @@ type Client struct {
 	provider Provider
 	logger   *slog.Logger
 	poller   *Poller
+	hedge    *hedge
+	slots    *slots
+	callback *callback
 }
 
 // New creates a captcha solving client for the given provider.
@@ -32,15 +36,34 @@ func New(provider Provider, opts ...Option) (*Client, error) {
 	}
 
 	if c.poller == nil {
-		c.poller = NewPoller(provider, DefaultPollerConfig(), WithPollerLogger(c.logger))
+		c.poller = NewPoller(provider, DefaultPollerConfig(),
+			WithPollerLogger(c.logger),
+			WithPollerProfiles(DefaultPollerProfiles()),
+			WithSolveTimes(NewSolveTimes(0)),
+		)
+	}
+
+	if c.hedge != nil {
+		c.hedge.poller = c.pollerFor(c.hedge.provider)
 	}
 
 	return c, nil
 }
 
 // Solve submits a task and blocks until the solution is ready, polling the
-// provider automatically.
+// provider automatically. When a hedge provider is configured with WithHedge,
+// the task may also be raced against it. When WithMaxInFlight is set, Solve
+// first waits for a slot at the priority set on ctx by WithPriority. When
+// WithCallback is set, Solve waits for the provider's callback before
+// falling back to polling. Tasks implementing SolutionValidator have their
+// solution checked before it is returned.
 func (c *Client) Solve(ctx context.Context, task Task) (*Solution, error) {
+	return c.solve(ctx, task, nil)
+}
+
+// solve implements Solve. When onCreated is non-nil it is called with the ID
+// of every provider task created for the solve.
+func (c *Client) solve(ctx context.Context, task Task, onCreated func(string)) (*Solution, error) {
 	if task == nil {
 		return nil, fmt.Errorf("task is nil: %w", ErrInvalidTask)
 	}
@@ -49,23 +72,117 @@ func (c *Client) Solve(ctx context.Context, task Task) (*Solution, error) {
 		return nil, fmt.Errorf("validate task: %w", err)
 	}
 
-	taskID, err := c.provider.CreateTask(ctx, task)
+	if c.slots != nil {
+		if err := c.slots.acquire(ctx); err != nil {
+			return nil, fmt.Errorf("waiting for slot: %w", err)
+		}
+		defer c.slots.release()
+	}
+
+	var (
+		result *TaskResult
+		err    error
+	)
+
+	if c.hedge != nil {
+		result, err = c.solveHedged(ctx, task, onCreated)
+	} else {
+		_, result, err = c.solveWith(ctx, c.provider, c.poller, task, onCreated)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := task.(SolutionValidator); ok {
+		if err := v.ValidateSolution(&result.Solution); err != nil {
+			return nil, fmt.Errorf("validate solution for task %s: %w", result.Solution.TaskID, err)
+		}
+	}
+
+	return &result.Solution, nil
+}
+
+// solveWith submits an already validated task to provider and polls it to a
+// terminal state, returning the provider task ID alongside the result. A ready
+// result carries the solve metadata. When onCreated is non-nil it is called
+// with the task ID before polling starts.
+func (c *Client) solveWith(ctx context.Context, provider Provider, poller *Poller, task Task, onCreated func(string)) (string, *TaskResult, error) {
+	createCtx := ctx
+	if c.callback != nil {
+		createCtx = WithCallbackURL(ctx, c.callback.url)
+	}
+
+	taskID, err := provider.CreateTask(createCtx, task)
 	if err != nil {
-		return nil, fmt.Errorf("creating task: %w", err)
+		return "", nil, fmt.Errorf("creating task: %w", err)
 	}
+	createdAt := time.Now()
 
 	c.logger.InfoContext(ctx, "task created",
 		slog.String("task_id", taskID),
 		slog.String("task_type", string(task.Type())),
-		slog.String("provider", c.provider.Name()),
+		slog.String("provider", provider.Name()),
 	)
 
-	result, err := c.poller.Poll(ctx, taskID)
-	if err != nil {
-		return nil, err
+	if onCreated != nil {
+		onCreated(taskID)
 	}
 
-	return &result.Solution, nil
+	var result *TaskResult
+
+	if c.callback != nil {
+		var done bool
+		if done, result, err = c.waitCallback(ctx, provider, taskID); done && err != nil {
+			return taskID, nil, err
+		}
+	}
+
+	if result == nil {
+		result, err = poller.PollTask(ctx, taskID, task.Type())
+		if err != nil {
+			return taskID, nil, err
+		}
+	}
+
+	owner, _ := backendTask(provider, taskID)
+	annotate(&result.Solution, owner.Name(), taskID, task.Type(), createdAt)
+
+	return taskID, result, nil
+}
+
+// annotate fills in the solve metadata of a ready solution, keeping the
+// timestamps the provider reported.
+func annotate(solution *Solution, provider, taskID string, taskType TaskType, createdAt time.Time) {
+	solution.Provider = provider
+	solution.TaskID = taskID
+
+	if solution.CreatedAt.IsZero() {
+		solution.CreatedAt = createdAt
+	}
+
+	if solution.SolvedAt.IsZero() {
+		solution.SolvedAt = time.Now()
+	}
+
+	if ttl, ok := TokenTTL(taskType); ok {
+		solution.ExpiresAt = solution.SolvedAt.Add(ttl)
+	}
+}
+
+// pollerFor returns a poller for another provider that shares the client
+// poller's configuration, profiles, solve-time history, and scheduling mode.
+func (c *Client) pollerFor(provider Provider) *Poller {
+	times := WithSolveTimes(c.poller.times)
+	if c.poller.adaptive {
+		times = WithAdaptivePolling(c.poller.times)
+	}
+
+	return NewPoller(provider, c.poller.config,
+		WithPollerLogger(c.logger),
+		WithPollerProfiles(c.poller.profiles),
+		times,
+	)
 }
 
 // CreateTask submits a task without polling and returns its provider task ID.
@@ -101,3 +218,49 @@ func (c *Client) GetTaskResult(ctx context.Context, taskID string) (*TaskResult,
 
 	return result, nil
 }
+
+// Balance returns the provider account balance. It returns
+// ErrUnsupportedOperation if the provider does not implement BalanceProvider.
+func (c *Client) Balance(ctx context.Context) (float64, error) {
+	bp, ok := c.provider.(BalanceProvider)
+	if !ok {
+		return 0, fmt.Errorf("balance for %s: %w", c.provider.Name(), ErrUnsupportedOperation)
+	}
+
+	balance, err := bp.Balance(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// ReportIncorrect reports the solution for a task as incorrect. It returns
+// ErrUnsupportedOperation if the provider does not implement Reporter.
+func (c *Client) ReportIncorrect(ctx context.Context, taskID string) error {
+	r, ok := c.provider.(Reporter)
+	if !ok {
+		return fmt.Errorf("report for %s: %w", c.provider.Name(), ErrUnsupportedOperation)
+	}
+
+	if err := r.ReportIncorrect(ctx, taskID); err != nil {
+		return fmt.Errorf("reporting incorrect task %s: %w", taskID, err)
+	}
+
+	return nil
+}
+
+// ReportCorrect reports the solution for a task as correct. It returns
+// ErrUnsupportedOperation if the provider does not implement Reporter.
+func (c *Client) ReportCorrect(ctx context.Context, taskID string) error {
+	r, ok := c.provider.(Reporter)
+	if !ok {
+		return fmt.Errorf("report for %s: %w", c.provider.Name(), ErrUnsupportedOperation)
+	}
+
+	if err := r.ReportCorrect(ctx, taskID); err != nil {
+		return fmt.Errorf("reporting correct task %s: %w", taskID, err)
+	}
+
+	return nil
+}
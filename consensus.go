@@ -0,0 +1,184 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// ConsensusConfig defines how SolveConsensus collects and compares answers.
+type ConsensusConfig struct {
+	// Votes is the number of times the task is solved. Defaults to 3.
+	Votes int
+
+	// Quorum is the number of matching answers required to accept a solution.
+	// Defaults to a strict majority of Votes.
+	Quorum int
+
+	// Providers spreads votes round-robin across several providers. Defaults
+	// to the client's provider.
+	Providers []Provider
+
+	// Normalize maps an answer to the form compared for agreement. Defaults
+	// to NormalizeAnswer.
+	Normalize func(string) string
+}
+
+// NormalizeAnswer trims surrounding whitespace and collapses internal runs of
+// whitespace to a single space. Wrap it with strings.ToLower for
+// case-insensitive agreement.
+func NormalizeAnswer(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// vote is one provider's answer in a consensus solve.
+type vote struct {
+	provider Provider
+	taskID   string
+	result   *TaskResult
+	err      error
+}
+
+// SolveConsensus solves an image-to-text or text captcha several times and
+// returns the answer that reaches the configured quorum in Solution.Text. It
+// returns as soon as an answer reaches quorum, abandoning outstanding solves.
+// Answers that disagree with the winner are reported as incorrect to providers
+// that implement Reporter. When no answer reaches quorum it returns an error
+// wrapping ErrNoConsensus. With WithMaxInFlight set, every vote waits for its
+// own slot.
+func (c *Client) SolveConsensus(ctx context.Context, task Task, config ConsensusConfig) (*Solution, error) {
+	if task == nil {
+		return nil, fmt.Errorf("task is nil: %w", ErrInvalidTask)
+	}
+
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("validate task: %w", err)
+	}
+
+	if t := task.Type(); t != TaskTypeImageToText && t != TaskTypeText {
+		return nil, fmt.Errorf("consensus for %s: %w", t, ErrUnsupportedTask)
+	}
+
+	if config.Votes <= 0 {
+		config.Votes = 3
+	}
+	if config.Quorum <= 0 {
+		config.Quorum = config.Votes/2 + 1
+	}
+	if config.Quorum > config.Votes {
+		return nil, fmt.Errorf("quorum %d exceeds %d votes: %w", config.Quorum, config.Votes, ErrInvalidTask)
+	}
+	if len(config.Providers) == 0 {
+		config.Providers = []Provider{c.provider}
+	}
+	if config.Normalize == nil {
+		config.Normalize = NormalizeAnswer
+	}
+
+	pollers := make([]*Poller, len(config.Providers))
+	for i, p := range config.Providers {
+		if p == c.provider {
+			pollers[i] = c.poller
+		} else {
+			pollers[i] = c.pollerFor(p)
+		}
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	solveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	votes := make(chan vote, config.Votes)
+	for i := range config.Votes {
+		provider, poller := config.Providers[i%len(config.Providers)], pollers[i%len(pollers)]
+
+		wg.Go(func() {
+			if c.slots != nil {
+				if err := c.slots.acquire(solveCtx); err != nil {
+					votes <- vote{provider: provider, err: fmt.Errorf("waiting for slot: %w", err)}
+
+					return
+				}
+				defer c.slots.release()
+			}
+
+			taskID, result, err := c.solveWith(solveCtx, provider, poller, task, nil)
+			votes <- vote{provider: provider, taskID: taskID, result: result, err: err}
+		})
+	}
+
+	groups := make(map[string][]vote)
+	var (
+		errs   []error
+		winner string
+	)
+
+	for range config.Votes {
+		v := <-votes
+		if v.err != nil {
+			errs = append(errs, v.err)
+
+			continue
+		}
+
+		answer := config.Normalize(v.result.Solution.Text)
+		if answer == "" {
+			errs = append(errs, fmt.Errorf("task %s: empty answer from %s", v.taskID, v.provider.Name()))
+
+			continue
+		}
+
+		groups[answer] = append(groups[answer], v)
+		if len(groups[answer]) >= config.Quorum {
+			winner = answer
+
+			break
+		}
+	}
+
+	cancel()
+
+	if winner == "" {
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("%w: %w", ErrNoConsensus, errors.Join(errs...))
+		}
+
+		return nil, fmt.Errorf("%d distinct answers: %w", len(groups), ErrNoConsensus)
+	}
+
+	for answer, losers := range groups {
+		if answer == winner {
+			continue
+		}
+
+		for _, v := range losers {
+			c.reportLoser(ctx, v)
+		}
+	}
+
+	solution := groups[winner][0].result.Solution
+
+	return &solution, nil
+}
+
+// reportLoser reports a disagreeing answer as incorrect, logging rather than
+// returning any failure since the consensus answer is already known.
+func (c *Client) reportLoser(ctx context.Context, v vote) {
+	r, ok := v.provider.(Reporter)
+	if !ok {
+		return
+	}
+
+	if err := r.ReportIncorrect(ctx, v.taskID); err != nil {
+		c.logger.WarnContext(ctx, "reporting losing answer failed",
+			slog.String("task_id", v.taskID),
+			slog.String("provider", v.provider.Name()),
+			slog.Any("error", err),
+		)
+	}
+}
@@ -0,0 +1,182 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// answerProvider solves every task with a fixed text answer after delay and
+// records the task IDs reported as incorrect.
+type answerProvider struct {
+	answer string
+	delay  time.Duration
+
+	mu       sync.Mutex
+	reported []string
+}
+
+func (p *answerProvider) CreateTask(context.Context, Task) (string, error) {
+	return p.answer, nil
+}
+
+func (p *answerProvider) GetTaskResult(ctx context.Context, _ string) (*TaskResult, error) {
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return &TaskResult{Status: TaskStatusReady, Solution: Solution{Text: p.answer}}, nil
+}
+
+func (p *answerProvider) ReportIncorrect(_ context.Context, taskID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.reported = append(p.reported, taskID)
+
+	return nil
+}
+
+func (p *answerProvider) ReportCorrect(context.Context, string) error {
+	return nil
+}
+
+func (p *answerProvider) Name() string {
+	return "answer"
+}
+
+func TestClientSolveConsensus(t *testing.T) {
+	loser := &answerProvider{answer: "xyz"}
+	first := &answerProvider{answer: "abc", delay: 50 * time.Millisecond}
+	second := &answerProvider{answer: " ABC ", delay: 50 * time.Millisecond}
+
+	client, err := New(first, WithPoller(NewPoller(first, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	solution, err := client.SolveConsensus(context.Background(), textTask{}, ConsensusConfig{
+		Providers: []Provider{loser, first, second},
+		Normalize: func(s string) string { return strings.ToLower(NormalizeAnswer(s)) },
+	})
+	if err != nil {
+		t.Fatalf("SolveConsensus: %v", err)
+	}
+
+	if got := strings.ToLower(NormalizeAnswer(solution.Text)); got != "abc" {
+		t.Errorf("text = %q, want abc", solution.Text)
+	}
+
+	if len(loser.reported) != 1 || loser.reported[0] != "xyz" {
+		t.Errorf("loser reports = %v, want [xyz]", loser.reported)
+	}
+
+	if len(first.reported)+len(second.reported) != 0 {
+		t.Errorf("winning answers were reported as incorrect")
+	}
+}
+
+func TestClientSolveConsensusErrors(t *testing.T) {
+	a := &answerProvider{answer: "a"}
+	b := &answerProvider{answer: "b"}
+
+	client, err := New(a, WithPoller(NewPoller(a, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		task      Task
+		config    ConsensusConfig
+		wantErrIs error
+	}{
+		{
+			name:      "disagreement",
+			task:      textTask{},
+			config:    ConsensusConfig{Votes: 2, Providers: []Provider{a, b}},
+			wantErrIs: ErrNoConsensus,
+		},
+		{
+			name:      "quorum exceeds votes",
+			task:      textTask{},
+			config:    ConsensusConfig{Votes: 2, Quorum: 3},
+			wantErrIs: ErrInvalidTask,
+		},
+		{
+			name:      "token task",
+			task:      tokenTask{},
+			wantErrIs: ErrUnsupportedTask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SolveConsensus(context.Background(), tt.task, tt.config)
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+// tokenTask is a minimal token-based task for client tests.
+type tokenTask struct{}
+
+func (tokenTask) Type() TaskType  { return TaskTypeTurnstile }
+func (tokenTask) Validate() error { return nil }
+
+// countingProvider answers like answerProvider and records the peak number of
+// tasks being solved at once.
+type countingProvider struct {
+	answerProvider
+
+	active atomic.Int32
+	peak   atomic.Int32
+}
+
+func (p *countingProvider) CreateTask(ctx context.Context, task Task) (string, error) {
+	n := p.active.Add(1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	return p.answerProvider.CreateTask(ctx, task)
+}
+
+func (p *countingProvider) GetTaskResult(ctx context.Context, taskID string) (*TaskResult, error) {
+	defer p.active.Add(-1)
+
+	return p.answerProvider.GetTaskResult(ctx, taskID)
+}
+
+func TestClientSolveConsensusMaxInFlight(t *testing.T) {
+	provider := &countingProvider{answerProvider: answerProvider{answer: "abc", delay: 5 * time.Millisecond}}
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())), WithMaxInFlight(1))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	solution, err := client.SolveConsensus(t.Context(), textTask{}, ConsensusConfig{Votes: 3, Quorum: 3})
+	if err != nil {
+		t.Fatalf("SolveConsensus: %v", err)
+	}
+
+	if solution.Text != "abc" {
+		t.Errorf("Text = %q, want abc", solution.Text)
+	}
+
+	if got := provider.peak.Load(); got != 1 {
+		t.Errorf("peak concurrent tasks = %d, want 1", got)
+	}
+}
@@ -54,4 +54,23 @@ var (
 	ErrUnsupportedTask = errors.New("unsupported task type")
 	// ErrNilProvider reports that a nil provider was passed to New.
 	ErrNilProvider = errors.New("provider cannot be nil")
+	// ErrUnsupportedOperation reports that a provider does not implement an
+	// optional capability such as balance lookup.
+	ErrUnsupportedOperation = errors.New("operation not supported by provider")
+	// ErrRateLimited reports that the provider throttled the request.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrProviderUnavailable reports that a provider is temporarily bypassed,
+	// for example because its circuit breaker is open.
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	// ErrNoConsensus reports that no answer reached the required quorum in
+	// SolveConsensus.
+	ErrNoConsensus = errors.New("no consensus among answers")
+	// ErrPoolClosed reports that a TokenPool was closed.
+	ErrPoolClosed = errors.New("token pool closed")
+	// ErrBudgetExhausted reports that a TokenPool has spent its solve budget
+	// and holds no tokens.
+	ErrBudgetExhausted = errors.New("solve budget exhausted")
+	// ErrInvalidSolution reports that a solution payload lacks the fields
+	// required by a typed solution.
+	ErrInvalidSolution = errors.New("invalid solution payload")
 )
@@ -0,0 +1,24 @@
+package unicap
+
+import "time"
+
+// tokenTTLs is how long solved tokens of each type stay usable, a little
+// under the lifetime the captcha vendor documents so that a token is not
+// handed out just as it expires: reCAPTCHA and hCaptcha tokens are accepted
+// for 120 seconds and Turnstile tokens for 300.
+var tokenTTLs = map[TaskType]time.Duration{
+	TaskTypeReCaptchaV2:           110 * time.Second,
+	TaskTypeReCaptchaV3:           110 * time.Second,
+	TaskTypeReCaptchaV2Enterprise: 110 * time.Second,
+	TaskTypeReCaptchaV3Enterprise: 110 * time.Second,
+	TaskTypeHCaptcha:              100 * time.Second,
+	TaskTypeTurnstile:             280 * time.Second,
+}
+
+// TokenTTL returns how long a freshly solved token of the given type can be
+// used, or false when the type has no known lifetime.
+func TokenTTL(taskType TaskType) (time.Duration, bool) {
+	ttl, ok := tokenTTLs[taskType]
+
+	return ttl, ok
+}
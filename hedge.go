@@ -0,0 +1,91 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// hedge holds the secondary provider raced against the primary by Solve.
+type hedge struct {
+	provider Provider
+	poller   *Poller
+	delay    time.Duration
+}
+
+// solveOutcome is the terminal result of solving a task with one provider.
+type solveOutcome struct {
+	result *TaskResult
+	err    error
+}
+
+// solveHedged runs the task on the primary provider and, if no solution has
+// arrived after the hedge delay or the primary fails first, submits it to the
+// secondary provider as well. The first ready solution wins and the other poll
+// is abandoned.
+func (c *Client) solveHedged(ctx context.Context, task Task, onCreated func(string)) (*TaskResult, error) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make(chan solveOutcome, 2)
+	run := func(provider Provider, poller *Poller) {
+		wg.Go(func() {
+			_, result, err := c.solveWith(ctx, provider, poller, task, onCreated)
+			outcomes <- solveOutcome{result: result, err: err}
+		})
+	}
+
+	run(c.provider, c.poller)
+	pending := 1
+	hedged := false
+
+	launch := func(reason string) {
+		hedged = true
+		pending++
+
+		c.logger.InfoContext(ctx, "hedging task",
+			slog.String("task_type", string(task.Type())),
+			slog.String("provider", c.hedge.provider.Name()),
+			slog.String("reason", reason),
+		)
+
+		run(c.hedge.provider, c.hedge.poller)
+	}
+
+	timer := time.NewTimer(c.hedge.delay)
+	defer timer.Stop()
+
+	var errs []error
+
+	for {
+		select {
+		case <-timer.C:
+			if !hedged && ctx.Err() == nil {
+				launch("delay elapsed")
+			}
+		case out := <-outcomes:
+			pending--
+
+			if out.err == nil {
+				return out.result, nil
+			}
+
+			errs = append(errs, out.err)
+
+			if !hedged && ctx.Err() == nil {
+				launch("primary failed")
+
+				continue
+			}
+
+			if pending == 0 {
+				return nil, errors.Join(errs...)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// textTask is a minimal task for client tests.
+type textTask struct{}
+
+func (textTask) Type() TaskType  { return TaskTypeText }
+func (textTask) Validate() error { return nil }
+
+func readyWith(token string) *TaskResult {
+	return &TaskResult{Status: TaskStatusReady, Solution: Solution{Token: token}}
+}
+
+func failed() *TaskResult {
+	return &TaskResult{
+		Status: TaskStatusFailed,
+		Error:  NewError("ERROR_X", "boom", "fake", false, ErrInvalidTask),
+	}
+}
+
+func TestClientSolveHedged(t *testing.T) {
+	tests := []struct {
+		name          string
+		primary       []step
+		secondary     []step
+		delay         time.Duration
+		wantToken     string
+		wantSecondary bool
+		wantErrIs     error
+	}{
+		{
+			name:      "primary wins before delay",
+			primary:   []step{{result: readyWith("primary")}},
+			secondary: []step{{result: readyWith("secondary")}},
+			delay:     time.Hour,
+			wantToken: "primary",
+		},
+		{
+			name:          "secondary wins when primary is slow",
+			primary:       []step{{result: processing()}},
+			secondary:     []step{{result: readyWith("secondary")}},
+			delay:         5 * time.Millisecond,
+			wantToken:     "secondary",
+			wantSecondary: true,
+		},
+		{
+			name:          "primary failure hedges immediately",
+			primary:       []step{{result: failed()}},
+			secondary:     []step{{result: readyWith("secondary")}},
+			delay:         time.Hour,
+			wantToken:     "secondary",
+			wantSecondary: true,
+		},
+		{
+			name:          "both fail",
+			primary:       []step{{result: failed()}},
+			secondary:     []step{{result: failed()}},
+			delay:         time.Hour,
+			wantSecondary: true,
+			wantErrIs:     ErrInvalidTask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeProvider{steps: tt.primary}
+			secondary := &fakeProvider{steps: tt.secondary}
+
+			client, err := New(primary,
+				WithPoller(NewPoller(primary, testConfig())),
+				WithHedge(secondary, tt.delay),
+			)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			solution, err := client.Solve(context.Background(), textTask{})
+
+			if got := secondary.calls > 0; got != tt.wantSecondary {
+				t.Errorf("secondary polled = %v, want %v", got, tt.wantSecondary)
+			}
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if solution.Token != tt.wantToken {
+				t.Errorf("token = %q, want %q", solution.Token, tt.wantToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package solverapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aarock1234/unicap"
+)
+
+var _ unicap.BatchProvider = (*Client)(nil)
+
+// BatchMapper describes a provider endpoint that returns the results of
+// several tasks in one GET request, typically part of a legacy protocol that
+// predates createTask / getTaskResult.
+type BatchMapper struct {
+	// URL is the absolute endpoint URL.
+	URL string
+
+	// MaxTasks caps the task IDs per request.
+	MaxTasks int
+
+	// Supports reports whether the endpoint returns complete results for
+	// tasks of the given type. The type is empty for tasks not created by
+	// this client.
+	Supports func(unicap.TaskType) bool
+
+	// Query returns the request query for the given task IDs.
+	Query func(apiKey string, taskIDs []string) url.Values
+
+	// Decode maps the response body to one result per task ID, in request
+	// order. taskTypes holds the type of each task.
+	Decode func(body []byte, taskTypes []unicap.TaskType) ([]*unicap.TaskResult, error)
+}
+
+// WithBatchMapper enables batched result retrieval through the given mapper.
+func WithBatchMapper(m BatchMapper) Option {
+	return func(c *Client) {
+		c.batch = &m
+	}
+}
+
+// WithBatchURL overrides the batch endpoint URL. Intended for testing.
+func WithBatchURL(u string) Option {
+	return func(c *Client) {
+		if u != "" {
+			c.batchURL = u
+		}
+	}
+}
+
+// Batchable reports whether the result of a task can be retrieved by
+// GetTaskResults.
+func (c *Client) Batchable(taskID string) bool {
+	return c.batch != nil && c.batch.Supports(c.types.get(taskID))
+}
+
+// GetTaskResults retrieves the results for the given task IDs, in order,
+// splitting them into requests of at most the mapper's MaxTasks IDs.
+func (c *Client) GetTaskResults(ctx context.Context, taskIDs []string) ([]*unicap.TaskResult, error) {
+	if c.batch == nil {
+		return nil, fmt.Errorf("batch results for %s: %w", c.name, unicap.ErrUnsupportedOperation)
+	}
+
+	endpoint := c.batch.URL
+	if c.batchURL != "" {
+		endpoint = c.batchURL
+	}
+
+	size := max(c.batch.MaxTasks, 1)
+	results := make([]*unicap.TaskResult, 0, len(taskIDs))
+
+	for start := 0; start < len(taskIDs); start += size {
+		chunk := taskIDs[start:min(start+size, len(taskIDs))]
+
+		body, err := c.doGet(ctx, endpoint, c.batch.Query(c.apiKey, chunk))
+		if err != nil {
+			return nil, err
+		}
+
+		types := make([]unicap.TaskType, len(chunk))
+		for i, id := range chunk {
+			types[i] = c.types.get(id)
+		}
+
+		decoded, err := c.batch.Decode(body, types)
+		if err != nil {
+			return nil, fmt.Errorf("decoding batch results: %w", err)
+		}
+
+		if len(decoded) != len(chunk) {
+			return nil, fmt.Errorf("batch returned %d results for %d tasks", len(decoded), len(chunk))
+		}
+
+		results = append(results, decoded...)
+	}
+
+	return results, nil
+}
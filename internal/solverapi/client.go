@@ -12,18 +12,32 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/aarock1234/unicap"
 	"github.com/aarock1234/unicap/tasks"
 )
 
-var _ unicap.Provider = (*Client)(nil)
+var (
+	_ unicap.Provider        = (*Client)(nil)
+	_ unicap.BalanceProvider = (*Client)(nil)
+	_ unicap.Reporter        = (*Client)(nil)
+)
 
 // TaskMapper converts a universal task into a provider-specific task payload.
 type TaskMapper func(unicap.Task) (any, error)
 
+// ReportMapper returns the endpoint and the extra request fields used to
+// report a solution for a task of the given type as correct or incorrect. The
+// task type is empty when the task was not created by this client. It returns
+// an error wrapping unicap.ErrUnsupportedOperation when the provider cannot
+// accept that report.
+type ReportMapper func(taskType unicap.TaskType, correct bool) (string, map[string]any, error)
+
 // Client is a provider client that speaks the createTask / getTaskResult
 // protocol.
 type Client struct {
@@ -34,6 +48,12 @@ type Client struct {
 	name    string
 	errors  *ErrorMapper
 	mapTask TaskMapper
+	report  ReportMapper
+	types   *taskTypeCache
+	ready   *readyResults
+
+	batch    *BatchMapper
+	batchURL string
 }
 
 // Option configures a Client.
@@ -66,6 +86,15 @@ func WithBaseURL(u string) Option {
 	}
 }
 
+// WithReportMapper enables solution feedback through the given mapper.
+func WithReportMapper(m ReportMapper) Option {
+	return func(c *Client) {
+		if m != nil {
+			c.report = m
+		}
+	}
+}
+
 // New creates a Client for the named provider.
 func New(name, baseURL, apiKey string, mapper TaskMapper, errs *ErrorMapper, opts ...Option) *Client {
 	c := &Client{
@@ -76,6 +105,8 @@ func New(name, baseURL, apiKey string, mapper TaskMapper, errs *ErrorMapper, opt
 		name:    name,
 		errors:  errs,
 		mapTask: mapper,
+		types:   newTaskTypeCache(taskTypeCacheSize),
+		ready:   newReadyResults(readyResultsSize),
 	}
 
 	for _, opt := range opts {
@@ -93,8 +124,9 @@ func (c *Client) CreateTask(ctx context.Context, task unicap.Task) (string, erro
 	}
 
 	req := createTaskRequest{
-		ClientKey: c.apiKey,
-		Task:      body,
+		ClientKey:   c.apiKey,
+		Task:        body,
+		CallbackURL: unicap.CallbackURL(ctx),
 	}
 
 	var resp createTaskResponse
@@ -106,11 +138,32 @@ func (c *Client) CreateTask(ctx context.Context, task unicap.Task) (string, erro
 		return "", c.errors.Error(resp.ErrorCode, resp.ErrorDescription)
 	}
 
-	return resp.TaskID.String(), nil
+	taskID := resp.TaskID.String()
+	c.types.put(taskID, task.Type())
+
+	// Recognition tasks may be solved within createTask; keep the result for
+	// the first GetTaskResult instead of asking for it again.
+	if mapStatus(resp.Status) == unicap.TaskStatusReady && resp.Solution != nil {
+		result := &unicap.TaskResult{
+			Status:   unicap.TaskStatusReady,
+			Solution: mapSolution(resp.Solution),
+		}
+		resp.taskMetadata.apply(&result.Solution)
+
+		c.ready.put(taskID, result)
+	}
+
+	return taskID, nil
 }
 
-// GetTaskResult retrieves the result for the given provider task ID.
+// GetTaskResult retrieves the result for the given provider task ID. A result
+// the provider returned when the task was created is returned without a
+// request.
 func (c *Client) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	if result := c.ready.take(taskID); result != nil {
+		return result, nil
+	}
+
 	req := getTaskResultRequest{
 		ClientKey: c.apiKey,
 		TaskID:    taskID,
@@ -128,10 +181,78 @@ func (c *Client) GetTaskResult(ctx context.Context, taskID string) (*unicap.Task
 		}, nil
 	}
 
-	return &unicap.TaskResult{
+	result := &unicap.TaskResult{
 		Status:   mapStatus(resp.Status),
 		Solution: mapSolution(resp.Solution),
-	}, nil
+	}
+	resp.taskMetadata.apply(&result.Solution)
+
+	return result, nil
+}
+
+// Balance returns the account balance reported by the provider.
+func (c *Client) Balance(ctx context.Context) (float64, error) {
+	req := getBalanceRequest{
+		ClientKey: c.apiKey,
+	}
+
+	var resp getBalanceResponse
+	if err := c.doJSON(ctx, "/getBalance", req, &resp); err != nil {
+		return 0, err
+	}
+
+	if resp.ErrorID != 0 {
+		return 0, c.errors.Error(resp.ErrorCode, resp.ErrorDescription)
+	}
+
+	return resp.Balance, nil
+}
+
+// ReportIncorrect reports the solution for the given task ID as incorrect.
+func (c *Client) ReportIncorrect(ctx context.Context, taskID string) error {
+	return c.sendReport(ctx, taskID, false)
+}
+
+// ReportCorrect reports the solution for the given task ID as correct.
+func (c *Client) ReportCorrect(ctx context.Context, taskID string) error {
+	return c.sendReport(ctx, taskID, true)
+}
+
+func (c *Client) sendReport(ctx context.Context, taskID string, correct bool) error {
+	if c.report == nil {
+		return fmt.Errorf("report for %s: %w", c.name, unicap.ErrUnsupportedOperation)
+	}
+
+	endpoint, fields, err := c.report(c.types.get(taskID), correct)
+	if err != nil {
+		return err
+	}
+
+	req := make(map[string]any, len(fields)+2)
+	maps.Copy(req, fields)
+	req["clientKey"] = c.apiKey
+	req["taskId"] = taskIDValue(taskID)
+
+	var resp reportResponse
+	if err := c.doJSON(ctx, endpoint, req, &resp); err != nil {
+		return err
+	}
+
+	if resp.ErrorID != 0 {
+		return c.errors.Error(resp.ErrorCode, resp.ErrorDescription)
+	}
+
+	return nil
+}
+
+// taskIDValue returns a numeric task ID as a JSON number and any other ID as a
+// string, so the ID is sent back in the type the provider issued it.
+func taskIDValue(taskID string) any {
+	if n, err := strconv.ParseInt(taskID, 10, 64); err == nil {
+		return n
+	}
+
+	return taskID
 }
 
 // Name returns the provider identifier.
@@ -163,19 +284,45 @@ func (c *Client) doJSON(ctx context.Context, endpoint string, reqBody, respBody
 
 	// The request body carries the client API key, so it is intentionally not
 	// logged here; the endpoint alone is sufficient for debugging.
+	body, err := c.send(ctx, req, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, respBody); err != nil {
+		return fmt.Errorf("unmarshaling response: %w", err)
+	}
+
+	return nil
+}
+
+// doGet sends a GET request to an absolute URL and returns the response body.
+func (c *Client) doGet(ctx context.Context, endpoint string, query url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// The query carries the client API key, so only the endpoint is logged.
+	return c.send(ctx, req, endpoint)
+}
+
+// send performs req and returns the body of a successful response. endpoint
+// identifies the request in logs.
+func (c *Client) send(ctx context.Context, req *http.Request, endpoint string) ([]byte, error) {
 	c.logger.DebugContext(ctx, "sending request",
 		slog.String("endpoint", endpoint),
 	)
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("sending request: %w", err)
+		return nil, fmt.Errorf("sending request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("reading response: %w", err)
+		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
 	c.logger.DebugContext(ctx, "received response",
@@ -183,20 +330,21 @@ func (c *Client) doJSON(ctx context.Context, endpoint string, reqBody, respBody
 		slog.String("body", string(body)),
 	)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("unexpected status code: %d: %w", resp.StatusCode, unicap.ErrRateLimited)
 	}
 
-	if err := json.Unmarshal(body, respBody); err != nil {
-		return fmt.Errorf("unmarshaling response: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	return body, nil
 }
 
 type createTaskRequest struct {
-	ClientKey string `json:"clientKey"`
-	Task      any    `json:"task"`
+	ClientKey   string `json:"clientKey"`
+	Task        any    `json:"task"`
+	CallbackURL string `json:"callbackUrl,omitempty"`
 }
 
 type createTaskResponse struct {
@@ -204,6 +352,12 @@ type createTaskResponse struct {
 	ErrorCode        string `json:"errorCode,omitempty"`
 	ErrorDescription string `json:"errorDescription,omitempty"`
 	TaskID           TaskID `json:"taskId,omitempty"`
+
+	// Status and Solution are set when the provider solves the task within
+	// createTask.
+	Status   string         `json:"status,omitempty"`
+	Solution map[string]any `json:"solution,omitempty"`
+	taskMetadata
 }
 
 type getTaskResultRequest struct {
@@ -217,4 +371,22 @@ type getTaskResultResponse struct {
 	ErrorDescription string         `json:"errorDescription,omitempty"`
 	Status           string         `json:"status,omitempty"`
 	Solution         map[string]any `json:"solution,omitempty"`
+	taskMetadata
+}
+
+type getBalanceRequest struct {
+	ClientKey string `json:"clientKey"`
+}
+
+type getBalanceResponse struct {
+	ErrorID          int     `json:"errorId"`
+	ErrorCode        string  `json:"errorCode,omitempty"`
+	ErrorDescription string  `json:"errorDescription,omitempty"`
+	Balance          float64 `json:"balance"`
+}
+
+type reportResponse struct {
+	ErrorID          int    `json:"errorId"`
+	ErrorCode        string `json:"errorCode,omitempty"`
+	ErrorDescription string `json:"errorDescription,omitempty"`
 }
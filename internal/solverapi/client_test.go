@@ -0,0 +1,289 @@
+package solverapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+// testServer answers each endpoint with a canned body and records the decoded
+// request bodies by endpoint.
+type testServer struct {
+	mu       sync.Mutex
+	requests map[string][]map[string]any
+}
+
+// newTestClient starts a server that answers requests using responses, keyed
+// by endpoint, and returns a Client pointed at it.
+func newTestClient(t *testing.T, responses map[string]string, opts ...Option) (*Client, *testServer) {
+	t.Helper()
+
+	ts := &testServer{requests: make(map[string][]map[string]any)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+
+		if req["clientKey"] != "key" {
+			t.Errorf("clientKey = %v, want key", req["clientKey"])
+		}
+
+		ts.mu.Lock()
+		ts.requests[r.URL.Path] = append(ts.requests[r.URL.Path], req)
+		ts.mu.Unlock()
+
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	errs := StandardErrorMapper("testprovider", []string{"ERROR_KEY_INVALID"}, nil, nil, nil)
+	mapper := func(task unicap.Task) (any, error) {
+		return map[string]any{"type": string(task.Type())}, nil
+	}
+
+	return New("testprovider", server.URL, "key", mapper, errs, opts...), ts
+}
+
+func (ts *testServer) last(endpoint string) map[string]any {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	reqs := ts.requests[endpoint]
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	return reqs[len(reqs)-1]
+}
+
+func TestClientBalance(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      float64
+		wantErrIs error
+	}{
+		{
+			name: "balance",
+			body: `{"errorId":0,"balance":12.5}`,
+			want: 12.5,
+		},
+		{
+			name:      "invalid key",
+			body:      `{"errorId":1,"errorCode":"ERROR_KEY_INVALID","errorDescription":"bad key"}`,
+			wantErrIs: unicap.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, map[string]string{"/getBalance": tt.body})
+
+			got, err := client.Balance(t.Context())
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Balance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientReport(t *testing.T) {
+	var gotType unicap.TaskType
+
+	report := func(taskType unicap.TaskType, correct bool) (string, map[string]any, error) {
+		gotType = taskType
+		if correct {
+			return "/reportCorrect", nil, nil
+		}
+
+		return "/reportIncorrect", map[string]any{"reason": "bad"}, nil
+	}
+
+	client, ts := newTestClient(t, map[string]string{
+		"/createTask":      `{"errorId":0,"taskId":42}`,
+		"/reportIncorrect": `{"errorId":0}`,
+		"/reportCorrect":   `{"errorId":0}`,
+	}, WithReportMapper(report))
+
+	taskID, err := client.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if err := client.ReportIncorrect(t.Context(), taskID); err != nil {
+		t.Fatalf("ReportIncorrect: %v", err)
+	}
+
+	if gotType != unicap.TaskTypeText {
+		t.Errorf("task type = %q, want %q", gotType, unicap.TaskTypeText)
+	}
+
+	req := ts.last("/reportIncorrect")
+	if req["taskId"] != float64(42) {
+		t.Errorf("taskId = %v, want 42", req["taskId"])
+	}
+
+	if req["reason"] != "bad" {
+		t.Errorf("reason = %v, want bad", req["reason"])
+	}
+
+	if err := client.ReportCorrect(t.Context(), "unknown"); err != nil {
+		t.Fatalf("ReportCorrect: %v", err)
+	}
+
+	if gotType != "" {
+		t.Errorf("task type = %q, want empty for unknown task", gotType)
+	}
+}
+
+func TestClientReportUnsupported(t *testing.T) {
+	client, _ := newTestClient(t, nil)
+
+	err := client.ReportIncorrect(t.Context(), "1")
+	if !errors.Is(err, unicap.ErrUnsupportedOperation) {
+		t.Fatalf("errors.Is(%v, ErrUnsupportedOperation) = false, want true", err)
+	}
+}
+
+func TestClientCreateTaskCallbackURL(t *testing.T) {
+	client, ts := newTestClient(t, map[string]string{
+		"/createTask": `{"errorId":0,"taskId":42}`,
+	})
+
+	task := &tasks.TextCaptchaTask{Question: "q"}
+
+	if _, err := client.CreateTask(t.Context(), task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if _, ok := ts.last("/createTask")["callbackUrl"]; ok {
+		t.Error("callbackUrl sent without a callback URL on the context")
+	}
+
+	ctx := unicap.WithCallbackURL(t.Context(), "https://example.com/callback")
+	if _, err := client.CreateTask(ctx, task); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if got := ts.last("/createTask")["callbackUrl"]; got != "https://example.com/callback" {
+		t.Errorf("callbackUrl = %v, want https://example.com/callback", got)
+	}
+}
+
+func TestClientGetTaskResultMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want unicap.Solution
+	}{
+		{
+			name: "anti-captcha metadata",
+			body: `{"errorId":0,"status":"ready","solution":{"text":"abc"},"cost":"0.00070","ip":"46.98.54.221","createTime":1700000000,"endTime":1700000007,"solveCount":1}`,
+			want: unicap.Solution{
+				Text:       "abc",
+				Cost:       0.0007,
+				SolverIP:   "46.98.54.221",
+				CreatedAt:  time.Unix(1700000000, 0),
+				SolvedAt:   time.Unix(1700000007, 0),
+				SolveCount: 1,
+			},
+		},
+		{
+			name: "numeric cost",
+			body: `{"errorId":0,"status":"ready","solution":{"text":"abc"},"cost":0.002}`,
+			want: unicap.Solution{Text: "abc", Cost: 0.002},
+		},
+		{
+			name: "no metadata",
+			body: `{"errorId":0,"status":"ready","solution":{"text":"abc"}}`,
+			want: unicap.Solution{Text: "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, map[string]string{"/getTaskResult": tt.body})
+
+			result, err := client.GetTaskResult(t.Context(), "1")
+			if err != nil {
+				t.Fatalf("GetTaskResult: %v", err)
+			}
+
+			got := result.Solution
+			if got.Text != tt.want.Text {
+				t.Errorf("Text = %q, want %q", got.Text, tt.want.Text)
+			}
+			if got.Cost != tt.want.Cost {
+				t.Errorf("Cost = %v, want %v", got.Cost, tt.want.Cost)
+			}
+			if got.SolverIP != tt.want.SolverIP {
+				t.Errorf("SolverIP = %q, want %q", got.SolverIP, tt.want.SolverIP)
+			}
+			if !got.CreatedAt.Equal(tt.want.CreatedAt) {
+				t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, tt.want.CreatedAt)
+			}
+			if !got.SolvedAt.Equal(tt.want.SolvedAt) {
+				t.Errorf("SolvedAt = %v, want %v", got.SolvedAt, tt.want.SolvedAt)
+			}
+			if got.SolveCount != tt.want.SolveCount {
+				t.Errorf("SolveCount = %d, want %d", got.SolveCount, tt.want.SolveCount)
+			}
+		})
+	}
+}
+
+func TestClientCreateTaskImmediateResult(t *testing.T) {
+	client, _ := newTestClient(t, map[string]string{
+		"/createTask": `{"errorId":0,"taskId":"abc","status":"ready","solution":{"objects":[0,3]}}`,
+	})
+
+	taskID, err := client.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	// No /getTaskResult response is configured, so a request would fail the
+	// test.
+	result, err := client.GetTaskResult(t.Context(), taskID)
+	if err != nil {
+		t.Fatalf("GetTaskResult: %v", err)
+	}
+
+	if result.Status != unicap.TaskStatusReady {
+		t.Errorf("Status = %v, want %v", result.Status, unicap.TaskStatusReady)
+	}
+
+	if _, ok := result.Solution.Extra["objects"]; !ok {
+		t.Errorf("Extra = %v, want objects", result.Solution.Extra)
+	}
+}
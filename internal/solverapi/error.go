@@ -4,15 +4,17 @@ import "github.com/aarock1234/unicap"
 
 // ErrorMapper maps provider-specific error codes to unicap sentinel errors.
 type ErrorMapper struct {
-	name     string
-	mappings map[string]error
+	name      string
+	mappings  map[string]error
+	retriable map[string]bool
 }
 
 // NewErrorMapper creates an error mapper for the named provider.
 func NewErrorMapper(name string) *ErrorMapper {
 	return &ErrorMapper{
-		name:     name,
-		mappings: make(map[string]error),
+		name:      name,
+		mappings:  make(map[string]error),
+		retriable: make(map[string]bool),
 	}
 }
 
@@ -24,19 +26,31 @@ func (m *ErrorMapper) Map(code string, sentinel error) *ErrorMapper {
 	return m
 }
 
+// MapRetriable registers a provider error code to a sentinel error for a
+// condition that clears on its own, such as throttling. It returns the mapper
+// to allow chaining.
+func (m *ErrorMapper) MapRetriable(code string, sentinel error) *ErrorMapper {
+	m.mappings[code] = sentinel
+	m.retriable[code] = true
+
+	return m
+}
+
 // Error builds a structured provider error. When the code maps to a known
 // sentinel, the returned error wraps it (so errors.Is works) and is marked
-// non-retriable; otherwise it is considered retriable.
+// non-retriable unless it was registered with MapRetriable; otherwise it is
+// considered retriable.
 func (m *ErrorMapper) Error(code, message string) *unicap.Error {
 	if sentinel, ok := m.mappings[code]; ok {
-		return unicap.NewError(code, message, m.name, false, sentinel)
+		return unicap.NewError(code, message, m.name, m.retriable[code], sentinel)
 	}
 
 	return unicap.NewError(code, message, m.name, true, nil)
 }
 
 // StandardErrorMapper returns an error mapper preloaded with the common
-// sentinel mappings for the given provider error codes.
+// sentinel mappings for the given provider error codes, plus the shared
+// ERROR_TOO_MUCH_REQUESTS throttling code.
 func StandardErrorMapper(name string, keyInvalidCodes, balanceCodes, taskNotFoundCodes, invalidTaskCodes []string) *ErrorMapper {
 	m := NewErrorMapper(name)
 
@@ -56,5 +70,7 @@ func StandardErrorMapper(name string, keyInvalidCodes, balanceCodes, taskNotFoun
 		m.Map(code, unicap.ErrInvalidTask)
 	}
 
+	m.MapRetriable("ERROR_TOO_MUCH_REQUESTS", unicap.ErrRateLimited)
+
 	return m
 }
@@ -34,6 +34,12 @@ func TestErrorMapperError(t *testing.T) {
 			wantSentinel:  unicap.ErrInsufficientFunds,
 			wantRetriable: false,
 		},
+		{
+			name:          "throttling maps to retriable sentinel",
+			code:          "ERROR_TOO_MUCH_REQUESTS",
+			wantSentinel:  unicap.ErrRateLimited,
+			wantRetriable: true,
+		},
 		{
 			name:          "unknown code is retriable",
 			code:          "ERROR_SOMETHING_ELSE",
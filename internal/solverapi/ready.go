@@ -0,0 +1,59 @@
+package solverapi
+
+import (
+	"sync"
+
+	"github.com/aarock1234/unicap"
+)
+
+// readyResultsSize bounds how many unclaimed immediate results a Client
+// holds.
+const readyResultsSize = 1024
+
+// readyResults holds results that a provider returned directly from
+// createTask, as CapSolver does for recognition tasks, until they are
+// claimed by GetTaskResult. It holds a fixed number of entries and evicts the
+// oldest first. It is safe for concurrent use.
+type readyResults struct {
+	mu      sync.Mutex
+	results map[string]*unicap.TaskResult
+	ring    []string
+	next    int
+}
+
+func newReadyResults(size int) *readyResults {
+	return &readyResults{
+		results: make(map[string]*unicap.TaskResult),
+		ring:    make([]string, size),
+	}
+}
+
+// put records the result for a task ID.
+func (r *readyResults) put(taskID string, result *unicap.TaskResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if evicted := r.ring[r.next]; evicted != "" {
+		delete(r.results, evicted)
+	}
+
+	r.ring[r.next] = taskID
+	r.next = (r.next + 1) % len(r.ring)
+	r.results[taskID] = result
+}
+
+// take removes and returns the result for a task ID, or nil when none is
+// held.
+func (r *readyResults) take(taskID string) *unicap.TaskResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result, ok := r.results[taskID]
+	if !ok {
+		return nil
+	}
+
+	delete(r.results, taskID)
+
+	return result
+}
@@ -1,6 +1,15 @@
 package solverapi
 
-import "github.com/aarock1234/unicap"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/aarock1234/unicap"
+)
 
 // mapStatus converts a provider status string to a unicap task status.
 func mapStatus(status string) unicap.TaskStatus {
@@ -35,5 +44,120 @@ func mapSolution(solution map[string]any) unicap.Solution {
 		sol.Text = text
 	}
 
+	if points, ok := solution["coordinates"].([]any); ok {
+		sol.Coordinates = mapCoordinates(points)
+	}
+
 	return sol
 }
+
+// mapCoordinates decodes a coordinate list given either as objects with x and
+// y (2Captcha) or as [x, y] arrays (Anti-Captcha). Malformed points are
+// skipped.
+func mapCoordinates(points []any) []unicap.Coordinate {
+	coords := make([]unicap.Coordinate, 0, len(points))
+
+	for _, point := range points {
+		var x, y any
+
+		switch p := point.(type) {
+		case map[string]any:
+			x, y = p["x"], p["y"]
+		case []any:
+			if len(p) < 2 {
+				continue
+			}
+
+			x, y = p[0], p[1]
+		default:
+			continue
+		}
+
+		cx, okX := coordinateValue(x)
+		cy, okY := coordinateValue(y)
+		if !okX || !okY {
+			continue
+		}
+
+		coords = append(coords, unicap.Coordinate{X: cx, Y: cy})
+	}
+
+	return coords
+}
+
+// coordinateValue converts a JSON number or numeric string to whole pixels.
+func coordinateValue(v any) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(math.Round(n)), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		return int(math.Round(f)), true
+	}
+
+	return 0, false
+}
+
+// taskMetadata is the solve metadata some providers (Anti-Captcha, 2Captcha)
+// return alongside a ready solution.
+type taskMetadata struct {
+	Cost       amount `json:"cost,omitempty"`
+	IP         string `json:"ip,omitempty"`
+	CreateTime int64  `json:"createTime,omitempty"`
+	EndTime    int64  `json:"endTime,omitempty"`
+	SolveCount int    `json:"solveCount,omitempty"`
+}
+
+// apply copies the reported metadata onto sol. Unix timestamps of zero are
+// left unset.
+func (m taskMetadata) apply(sol *unicap.Solution) {
+	sol.Cost = float64(m.Cost)
+	sol.SolverIP = m.IP
+	sol.SolveCount = m.SolveCount
+
+	if m.CreateTime > 0 {
+		sol.CreatedAt = time.Unix(m.CreateTime, 0)
+	}
+
+	if m.EndTime > 0 {
+		sol.SolvedAt = time.Unix(m.EndTime, 0)
+	}
+}
+
+// amount is a price that decodes from either a JSON number or a numeric JSON
+// string, as Anti-Captcha reports cost as a string such as "0.00070".
+type amount float64
+
+// UnmarshalJSON accepts a JSON number, a numeric JSON string, or null.
+func (a *amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("unmarshaling amount: %w", err)
+		}
+
+		if s == "" {
+			return nil
+		}
+
+		data = []byte(s)
+	}
+
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("unmarshaling amount: %w", err)
+	}
+
+	*a = amount(f)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package solverapi
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aarock1234/unicap"
+)
+
+func TestMapSolutionCoordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		solution map[string]any
+		want     []unicap.Coordinate
+	}{
+		{
+			name: "objects",
+			solution: map[string]any{"coordinates": []any{
+				map[string]any{"x": float64(57), "y": float64(75)},
+				map[string]any{"x": "12", "y": "34.6"},
+			}},
+			want: []unicap.Coordinate{{X: 57, Y: 75}, {X: 12, Y: 35}},
+		},
+		{
+			name: "arrays",
+			solution: map[string]any{"coordinates": []any{
+				[]any{float64(1), float64(2)},
+				[]any{float64(3), float64(4), float64(5), float64(6)},
+			}},
+			want: []unicap.Coordinate{{X: 1, Y: 2}, {X: 3, Y: 4}},
+		},
+		{
+			name: "malformed points skipped",
+			solution: map[string]any{"coordinates": []any{
+				[]any{float64(1)},
+				map[string]any{"x": "a", "y": float64(2)},
+				"point",
+				map[string]any{"x": float64(7), "y": float64(8)},
+			}},
+			want: []unicap.Coordinate{{X: 7, Y: 8}},
+		},
+		{
+			name:     "no coordinates",
+			solution: map[string]any{"text": "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapSolution(tt.solution).Coordinates
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Coordinates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package solverapi
+
+import (
+	"sync"
+
+	"github.com/aarock1234/unicap"
+)
+
+// taskTypeCacheSize bounds how many recent task IDs a Client remembers the
+// task type for.
+const taskTypeCacheSize = 16384
+
+// taskTypeCache remembers the task type of recently created tasks so feedback
+// can be routed to type-specific report endpoints and batched result requests
+// are limited to supported types. It holds a fixed number of entries and
+// evicts the oldest first. It is safe for concurrent use.
+type taskTypeCache struct {
+	mu    sync.Mutex
+	types map[string]unicap.TaskType
+	ring  []string
+	next  int
+}
+
+func newTaskTypeCache(size int) *taskTypeCache {
+	return &taskTypeCache{
+		types: make(map[string]unicap.TaskType, size),
+		ring:  make([]string, size),
+	}
+}
+
+// put records the task type for a task ID.
+func (c *taskTypeCache) put(taskID string, taskType unicap.TaskType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.types[taskID]; ok {
+		c.types[taskID] = taskType
+
+		return
+	}
+
+	if evicted := c.ring[c.next]; evicted != "" {
+		delete(c.types, evicted)
+	}
+
+	c.ring[c.next] = taskID
+	c.next = (c.next + 1) % len(c.ring)
+	c.types[taskID] = taskType
+}
+
+// get returns the recorded task type for a task ID, or the empty type when
+// the ID is unknown.
+func (c *taskTypeCache) get(taskID string) unicap.TaskType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.types[taskID]
+}
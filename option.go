@@ -1,6 +1,9 @@
 package unicap
 
-import "log/slog"
+import (
+	"log/slog"
+	"time"
+)
 
 // Option configures a Client.
 type Option func(*Client)
@@ -23,3 +26,39 @@ func WithPoller(poller *Poller) Option {
 		}
 	}
 }
+
+// WithHedge races a secondary provider against the primary in Solve. If the
+// primary has not produced a solution within delay, or fails before then, the
+// task is also submitted to secondary; the first ready solution wins. The
+// secondary is polled with the same configuration as the primary.
+func WithHedge(secondary Provider, delay time.Duration) Option {
+	return func(c *Client) {
+		if secondary != nil {
+			c.hedge = &hedge{provider: secondary, delay: delay}
+		}
+	}
+}
+
+// WithMaxInFlight caps the number of concurrent solves. Each Solve call holds
+// one slot, including a hedged one, and each SolveConsensus vote holds its
+// own. Excess solves queue by the priority set with WithPriority and give up
+// when their context ends. A non-positive limit leaves solving unbounded.
+func WithMaxInFlight(limit int) Option {
+	return func(c *Client) {
+		if limit > 0 {
+			c.slots = newSlots(limit)
+		}
+	}
+}
+
+// WithCallback makes Solve ask providers to notify url, which must route to
+// receiver, when a task is done instead of polling it. Solve waits up to grace
+// for the callback, then fetches the result once; if no callback arrives in
+// time, it falls back to the poller, whose timeout then applies.
+func WithCallback(receiver *CallbackReceiver, url string, grace time.Duration) Option {
+	return func(c *Client) {
+		if receiver != nil && url != "" {
+			c.callback = &callback{receiver: receiver, url: url, grace: grace}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"time"
 )
 
@@ -15,13 +16,20 @@ const maxPollErrors = 3
 
 // Poller repeatedly checks a provider for task completion.
 type Poller struct {
-	provider Provider
-	config   PollerConfig
-	logger   *slog.Logger
+	provider  Provider
+	config    PollerConfig
+	profiles  PollerProfiles
+	times     *SolveTimes
+	adaptive  bool
+	logger    *slog.Logger
+	scheduler *scheduler
 }
 
-// PollerConfig defines polling behavior.
+// PollerConfig defines polling behavior. The first check is made after
+// InitialDelay; later checks start InitialInterval apart and back off by
+// Multiplier up to MaxInterval until Timeout elapses.
 type PollerConfig struct {
+	InitialDelay    time.Duration
 	InitialInterval time.Duration
 	MaxInterval     time.Duration
 	Timeout         time.Duration
@@ -38,6 +46,65 @@ func DefaultPollerConfig() PollerConfig {
 	}
 }
 
+// PollerProfiles overrides the poller configuration for specific task types.
+type PollerProfiles map[TaskType]PollerConfig
+
+// DefaultPollerProfiles returns polling profiles that follow provider
+// guidance on typical solve times: image and text captchas are usually
+// answered within 3-5 seconds, most token captchas within 10-30 seconds, and
+// reCAPTCHA Enterprise and FunCaptcha can take up to a minute. Task types
+// without a profile use the poller's base configuration.
+func DefaultPollerProfiles() PollerProfiles {
+	image := PollerConfig{
+		InitialDelay:    3 * time.Second,
+		InitialInterval: time.Second,
+		MaxInterval:     3 * time.Second,
+		Timeout:         2 * time.Minute,
+		Multiplier:      1.5,
+	}
+
+	token := PollerConfig{
+		InitialDelay:    5 * time.Second,
+		InitialInterval: 3 * time.Second,
+		MaxInterval:     10 * time.Second,
+		Timeout:         3 * time.Minute,
+		Multiplier:      1.5,
+	}
+
+	recaptcha := PollerConfig{
+		InitialDelay:    10 * time.Second,
+		InitialInterval: 3 * time.Second,
+		MaxInterval:     10 * time.Second,
+		Timeout:         5 * time.Minute,
+		Multiplier:      1.5,
+	}
+
+	slow := PollerConfig{
+		InitialDelay:    15 * time.Second,
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     15 * time.Second,
+		Timeout:         5 * time.Minute,
+		Multiplier:      1.5,
+	}
+
+	return PollerProfiles{
+		TaskTypeImageToText:           image,
+		TaskTypeImageCoordinates:      image,
+		TaskTypeGridClassification:    image,
+		TaskTypeAudio:                 image,
+		TaskTypeRotate:                image,
+		TaskTypeSlider:                image,
+		TaskTypeText:                  image,
+		TaskTypeTurnstile:             token,
+		TaskTypeHCaptcha:              token,
+		TaskTypeReCaptchaV2:           recaptcha,
+		TaskTypeReCaptchaV3:           recaptcha,
+		TaskTypeReCaptchaV2Enterprise: slow,
+		TaskTypeReCaptchaV3Enterprise: slow,
+		TaskTypeFunCaptcha:            slow,
+	}
+}
+
 // PollerOption configures a Poller.
 type PollerOption func(*Poller)
 
@@ -50,6 +117,14 @@ func WithPollerLogger(logger *slog.Logger) PollerOption {
 	}
 }
 
+// WithPollerProfiles sets per-task-type configurations that override the
+// poller's base configuration when polling with PollTask.
+func WithPollerProfiles(profiles PollerProfiles) PollerOption {
+	return func(p *Poller) {
+		p.profiles = maps.Clone(profiles)
+	}
+}
+
 // NewPoller creates a poller for the given provider and config.
 func NewPoller(provider Provider, config PollerConfig, opts ...PollerOption) *Poller {
 	p := &Poller{
@@ -69,74 +144,157 @@ func NewPoller(provider Provider, config PollerConfig, opts ...PollerOption) *Po
 // cancelled, or the configured timeout elapses. Transient result-fetch
 // failures are tolerated up to maxPollErrors before the error is returned.
 func (p *Poller) Poll(ctx context.Context, taskID string) (*TaskResult, error) {
-	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	return p.PollTask(ctx, taskID, "")
+}
+
+// PollTask is like Poll but uses the profile for taskType, if the poller has
+// one, in place of the base configuration.
+func (p *Poller) PollTask(ctx context.Context, taskID string, taskType TaskType) (*TaskResult, error) {
+	state := p.newPollState(taskType)
+
+	ctx, cancel := context.WithTimeout(ctx, state.config.Timeout)
 	defer cancel()
 
-	interval := p.config.InitialInterval
-	consecutiveErrors := 0
+	if p.scheduler != nil {
+		return p.scheduler.poll(ctx, taskID, state)
+	}
 
-	// Fire immediately on the first iteration, then back off between checks so
-	// an already-solved task returns without waiting a full interval.
-	timer := time.NewTimer(0)
+	// Fire after the initial delay, which is zero unless configured, then back
+	// off between checks so an already-solved task returns without waiting a
+	// full interval.
+	timer := time.NewTimer(state.next)
 	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, fmt.Errorf("polling task %s: %w", taskID, ErrTimeout)
-			}
-
-			return nil, fmt.Errorf("polling task %s: %w", taskID, ctx.Err())
+			return nil, pollError(ctx, taskID)
 		case <-timer.C:
 		}
 
 		result, err := p.provider.GetTaskResult(ctx, taskID)
-		if err != nil {
-			consecutiveErrors++
-			if consecutiveErrors > maxPollErrors {
-				return nil, fmt.Errorf("polling task %s: %w", taskID, err)
-			}
+		if done, result, err := p.observe(ctx, taskID, &state, result, err); done {
+			return result, err
+		}
 
-			p.logger.DebugContext(ctx, "transient poll error, retrying",
-				slog.String("task_id", taskID),
-				slog.Int("consecutive_errors", consecutiveErrors),
-				slog.Any("error", err),
-			)
+		timer.Reset(state.next)
+	}
+}
+
+// configFor returns the configuration for polling a task of the given type.
+func (p *Poller) configFor(taskType TaskType) PollerConfig {
+	if config, ok := p.profiles[taskType]; ok {
+		return config
+	}
+
+	return p.config
+}
+
+// pollState is the backoff state of one task between result checks.
+type pollState struct {
+	config            PollerConfig
+	taskType          TaskType
+	start             time.Time
+	interval          time.Duration
+	next              time.Duration
+	polls             int
+	consecutiveErrors int
+}
+
+// newPollState returns the state for a task of the given type that is about
+// to be polled, with next set to the delay before the first check.
+func (p *Poller) newPollState(taskType TaskType) pollState {
+	config := p.configFor(taskType)
+
+	state := pollState{
+		config:   config,
+		taskType: taskType,
+		start:    time.Now(),
+		interval: config.InitialInterval,
+		next:     config.InitialDelay,
+	}
+
+	if w, ok := p.learned(taskType); ok {
+		state.next = jitter(w.p25)
+	}
+
+	return state
+}
 
-			timer.Reset(interval)
+// observe applies the outcome of one result check to a task's poll state. It
+// reports done with the terminal result or error, or not done when the task
+// should be checked again after state.next.
+func (p *Poller) observe(ctx context.Context, taskID string, state *pollState, result *TaskResult, err error) (bool, *TaskResult, error) {
+	state.polls++
 
-			continue
+	// A throttled check says nothing about the task, which the provider is
+	// still solving, so it is retried like a transport error rather than
+	// failing a task that has already been paid for.
+	if err == nil && result != nil && result.Status == TaskStatusFailed &&
+		result.Error != nil && errors.Is(result.Error, ErrRateLimited) {
+		err = result.Error
+	}
+
+	if err != nil {
+		state.consecutiveErrors++
+		if state.consecutiveErrors > maxPollErrors {
+			return true, nil, fmt.Errorf("polling task %s: %w", taskID, err)
 		}
 
-		consecutiveErrors = 0
+		p.logger.DebugContext(ctx, "transient poll error, retrying",
+			slog.String("task_id", taskID),
+			slog.Int("consecutive_errors", state.consecutiveErrors),
+			slog.Any("error", err),
+		)
 
-		switch result.Status {
-		case TaskStatusReady:
-			p.logger.InfoContext(ctx, "task completed",
-				slog.String("task_id", taskID),
-				slog.String("provider", p.provider.Name()),
-			)
+		state.next = state.interval
 
-			return result, nil
-		case TaskStatusFailed:
-			if result.Error != nil {
-				return nil, result.Error
-			}
+		return false, nil, nil
+	}
 
-			return nil, fmt.Errorf("polling task %s: %w", taskID, ErrInvalidTask)
-		case TaskStatusPending, TaskStatusProcessing:
-			interval = nextInterval(interval, p.config)
+	state.consecutiveErrors = 0
 
-			p.logger.DebugContext(ctx, "task still processing",
-				slog.String("task_id", taskID),
-				slog.String("status", string(result.Status)),
-				slog.Duration("next_check", interval),
-			)
+	switch result.Status {
+	case TaskStatusReady:
+		if p.times != nil {
+			p.times.Record(p.provider.Name(), state.taskType, time.Since(state.start))
+		}
 
-			timer.Reset(interval)
+		result.Solution.Polls = state.polls
+
+		p.logger.InfoContext(ctx, "task completed",
+			slog.String("task_id", taskID),
+			slog.String("provider", p.provider.Name()),
+		)
+
+		return true, result, nil
+	case TaskStatusFailed:
+		if result.Error != nil {
+			return true, nil, result.Error
 		}
+
+		return true, nil, fmt.Errorf("polling task %s: %w", taskID, ErrInvalidTask)
 	}
+
+	p.backoff(state)
+
+	p.logger.DebugContext(ctx, "task still processing",
+		slog.String("task_id", taskID),
+		slog.String("status", string(result.Status)),
+		slog.Duration("next_check", state.next),
+	)
+
+	return false, nil, nil
+}
+
+// pollError returns the error for a poll whose context has ended, reporting
+// an elapsed deadline as ErrTimeout.
+func pollError(ctx context.Context, taskID string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("polling task %s: %w", taskID, ErrTimeout)
+	}
+
+	return fmt.Errorf("polling task %s: %w", taskID, ctx.Err())
 }
 
 // nextInterval grows the poll interval by the configured multiplier, capped at
@@ -53,6 +53,10 @@ func processing() *TaskResult {
 
 func TestPollerPoll(t *testing.T) {
 	transient := errors.New("transient")
+	throttled := &TaskResult{
+		Status: TaskStatusFailed,
+		Error:  NewError("ERROR_TOO_MUCH_REQUESTS", "slow down", "fake", true, ErrRateLimited),
+	}
 
 	tests := []struct {
 		name      string
@@ -101,6 +105,22 @@ func TestPollerPoll(t *testing.T) {
 			wantErr:   true,
 			wantErrIs: transient,
 		},
+		{
+			name:      "retries throttled poll",
+			steps:     []step{{result: throttled}, {result: ready()}},
+			wantToken: "solved",
+		},
+		{
+			name: "gives up after persistent throttling",
+			steps: []step{
+				{result: throttled},
+				{result: throttled},
+				{result: throttled},
+				{result: throttled},
+			},
+			wantErr:   true,
+			wantErrIs: ErrRateLimited,
+		},
 	}
 
 	for _, tt := range tests {
@@ -159,3 +179,48 @@ func TestPollerPollContextCancelled(t *testing.T) {
 		t.Fatalf("errors.Is(%v, context.Canceled) = false, want true", err)
 	}
 }
+
+func TestPollerPollTaskProfile(t *testing.T) {
+	base := testConfig()
+	base.Timeout = time.Minute
+
+	profile := testConfig()
+	profile.Timeout = 15 * time.Millisecond
+
+	provider := &fakeProvider{steps: []step{{result: processing()}}}
+	poller := NewPoller(provider, base, WithPollerProfiles(PollerProfiles{TaskTypeText: profile}))
+
+	_, err := poller.PollTask(context.Background(), "task-1", TaskTypeText)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("errors.Is(%v, ErrTimeout) = false, want true", err)
+	}
+}
+
+func TestPollerPollInitialDelay(t *testing.T) {
+	config := testConfig()
+	config.InitialDelay = 20 * time.Millisecond
+
+	provider := &fakeProvider{steps: []step{{result: ready()}}}
+	poller := NewPoller(provider, config)
+
+	start := time.Now()
+	if _, err := poller.Poll(context.Background(), "task-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < config.InitialDelay {
+		t.Errorf("first check after %v, want at least %v", elapsed, config.InitialDelay)
+	}
+}
+
+func TestDefaultPollerProfiles(t *testing.T) {
+	for taskType, config := range DefaultPollerProfiles() {
+		if config.InitialInterval <= 0 || config.InitialInterval > config.MaxInterval {
+			t.Errorf("%s: InitialInterval = %v, want in (0, %v]", taskType, config.InitialInterval, config.MaxInterval)
+		}
+
+		if config.InitialDelay >= config.Timeout || config.Multiplier < 1 {
+			t.Errorf("%s: profile %+v is not usable", taskType, config)
+		}
+	}
+}
@@ -15,3 +15,61 @@ type Provider interface {
 	// Name returns the provider's identifier
 	Name() string
 }
+
+// BalanceProvider is implemented by providers that can report the account
+// balance.
+type BalanceProvider interface {
+	// Balance returns the remaining account balance in the provider's billing
+	// currency
+	Balance(ctx context.Context) (float64, error)
+}
+
+// Reporter is implemented by providers that accept feedback on solution
+// quality. Reporting incorrect solutions can earn refunds and improves the
+// provider's worker routing.
+type Reporter interface {
+	// ReportIncorrect reports that the solution for a task was rejected
+	ReportIncorrect(ctx context.Context, taskID string) error
+
+	// ReportCorrect reports that the solution for a task was accepted
+	ReportCorrect(ctx context.Context, taskID string) error
+}
+
+// BatchProvider is implemented by providers that can retrieve the results of
+// several tasks in one request. A Poller configured with WithScheduler uses it
+// to coalesce result checks.
+type BatchProvider interface {
+	// Batchable reports whether the result of a task can be retrieved by
+	// GetTaskResults
+	Batchable(taskID string) bool
+
+	// GetTaskResults retrieves the results for the given task IDs, in order
+	GetTaskResults(ctx context.Context, taskIDs []string) ([]*TaskResult, error)
+}
+
+// Delegator is implemented by providers that hand tasks to other providers,
+// such as composites and decorators. The client uses it to find the task ID a
+// backend issued, which is what that backend's callbacks carry.
+type Delegator interface {
+	// Delegate returns the provider that owns taskID and the task ID that
+	// provider issued, or false when taskID was not issued by this provider
+	Delegate(taskID string) (Provider, string, bool)
+}
+
+// backendTask follows Delegators from provider down to the provider that
+// issued taskID, returning it and its own task ID.
+func backendTask(provider Provider, taskID string) (Provider, string) {
+	for {
+		d, ok := provider.(Delegator)
+		if !ok {
+			return provider, taskID
+		}
+
+		next, nextID, ok := d.Delegate(taskID)
+		if !ok || next == nil {
+			return provider, taskID
+		}
+
+		provider, taskID = next, nextID
+	}
+}
@@ -48,5 +48,30 @@ func New(apiKey string, opts ...Option) (unicap.Provider, error) {
 		[]string{"ERROR_WRONG_TASK_DATA"},
 	)
 
+	opts = append([]Option{solverapi.WithReportMapper(mapReport)}, opts...)
+
 	return solverapi.New(name, baseURL, apiKey, mapTask, errs, opts...), nil
 }
+
+// mapReport routes solution feedback to the type-specific report endpoints.
+// Anti-Captcha accepts incorrect reports for image and reCAPTCHA tasks, and
+// correct reports for reCAPTCHA tasks only.
+func mapReport(taskType unicap.TaskType, correct bool) (string, map[string]any, error) {
+	switch taskType {
+	case unicap.TaskTypeImageToText:
+		if !correct {
+			return "/reportIncorrectImageCaptcha", nil, nil
+		}
+	case unicap.TaskTypeReCaptchaV2,
+		unicap.TaskTypeReCaptchaV3,
+		unicap.TaskTypeReCaptchaV2Enterprise,
+		unicap.TaskTypeReCaptchaV3Enterprise:
+		if correct {
+			return "/reportCorrectRecaptcha", nil, nil
+		}
+
+		return "/reportIncorrectRecaptcha", nil, nil
+	}
+
+	return "", nil, fmt.Errorf("report for %q task: %w", taskType, unicap.ErrUnsupportedOperation)
+}
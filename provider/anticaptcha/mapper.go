@@ -97,6 +97,13 @@ type imageToTextTask struct {
 	LanguagePool string `json:"languagePool,omitempty"`
 }
 
+type imageToCoordinatesTask struct {
+	Type    string `json:"type"`
+	Body    string `json:"body"`
+	Comment string `json:"comment,omitempty"`
+	Mode    string `json:"mode"`
+}
+
 type friendlyCaptchaTask struct {
 	Type       string `json:"type"`
 	WebsiteURL string `json:"websiteURL"`
@@ -132,6 +139,8 @@ func mapTask(task unicap.Task) (any, error) {
 		return mapGeeTestV4(t), nil
 	case *tasks.ImageToTextTask:
 		return mapImageToText(t), nil
+	case *tasks.ImageCoordinatesTask:
+		return mapImageCoordinates(t)
 	case *tasks.FriendlyCaptchaTask:
 		return mapFriendlyCaptcha(t), nil
 	case *tasks.ProsopoTask:
@@ -294,6 +303,21 @@ func mapImageToText(task *tasks.ImageToTextTask) imageToTextTask {
 	}
 }
 
+// mapImageCoordinates maps a click task to Anti-Captcha's point mode, which
+// takes the instruction as text only and has no click limit.
+func mapImageCoordinates(task *tasks.ImageCoordinatesTask) (imageToCoordinatesTask, error) {
+	if task.Instruction == "" {
+		return imageToCoordinatesTask{}, fmt.Errorf("instruction image: %w", unicap.ErrUnsupportedTask)
+	}
+
+	return imageToCoordinatesTask{
+		Type:    "ImageToCoordinatesTask",
+		Body:    task.Body,
+		Comment: task.Instruction,
+		Mode:    "points",
+	}, nil
+}
+
 func mapFriendlyCaptcha(task *tasks.FriendlyCaptchaTask) friendlyCaptchaTask {
 	result := friendlyCaptchaTask{
 		Type:       "FriendlyCaptchaTaskProxyless",
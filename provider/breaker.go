@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aarock1234/unicap"
+)
+
+var (
+	_ unicap.Provider        = (*Breaker)(nil)
+	_ unicap.Reporter        = (*Breaker)(nil)
+	_ unicap.BalanceProvider = (*Breaker)(nil)
+	_ unicap.Delegator       = (*Breaker)(nil)
+)
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed passes calls through to the provider.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails calls fast with unicap.ErrProviderUnavailable.
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial call through to probe recovery.
+	BreakerHalfOpen
+)
+
+// String returns the state name.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// Breaker is a provider decorator that stops calling an unhealthy provider.
+// The circuit opens after FailureThreshold consecutive transport failures or
+// retriable provider errors, or immediately on an invalid API key or
+// insufficient funds. While open, calls fail fast with
+// unicap.ErrProviderUnavailable; after Cooldown a single trial call is let
+// through and its outcome closes or reopens the circuit. It is safe for
+// concurrent use.
+type Breaker struct {
+	provider unicap.Provider
+	config   BreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// BreakerConfig defines when a Breaker opens and how long it stays open.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit.
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before a trial call.
+	Cooldown time.Duration
+}
+
+// DefaultBreakerConfig returns sensible defaults for a Breaker.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// NewBreaker wraps provider in a circuit breaker. Zero-valued config fields
+// fall back to DefaultBreakerConfig.
+func NewBreaker(provider unicap.Provider, config BreakerConfig) (*Breaker, error) {
+	if provider == nil {
+		return nil, unicap.ErrNilProvider
+	}
+
+	defaults := DefaultBreakerConfig()
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaults.FailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaults.Cooldown
+	}
+
+	return &Breaker{provider: provider, config: config}, nil
+}
+
+// CreateTask submits the task unless the circuit is open.
+func (b *Breaker) CreateTask(ctx context.Context, task unicap.Task) (string, error) {
+	probe, err := b.allow()
+	if err != nil {
+		return "", err
+	}
+
+	taskID, err := b.provider.CreateTask(ctx, task)
+	b.record(ctx, probe, err)
+
+	return taskID, err
+}
+
+// GetTaskResult retrieves the task result unless the circuit is open.
+func (b *Breaker) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	probe, err := b.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.provider.GetTaskResult(ctx, taskID)
+	if err == nil && result.Error != nil && isFatal(result.Error) {
+		b.record(ctx, probe, result.Error)
+	} else {
+		b.record(ctx, probe, err)
+	}
+
+	return result, err
+}
+
+// ReportIncorrect forwards the report to the wrapped provider.
+func (b *Breaker) ReportIncorrect(ctx context.Context, taskID string) error {
+	return report(ctx, b.provider, taskID, false)
+}
+
+// ReportCorrect forwards the report to the wrapped provider.
+func (b *Breaker) ReportCorrect(ctx context.Context, taskID string) error {
+	return report(ctx, b.provider, taskID, true)
+}
+
+// Balance returns the wrapped provider's account balance. It bypasses the
+// circuit so callers can check whether a drained account has been topped up.
+func (b *Breaker) Balance(ctx context.Context) (float64, error) {
+	return balance(ctx, b.provider)
+}
+
+// Delegate returns the wrapped provider, which issued the task ID unchanged.
+func (b *Breaker) Delegate(taskID string) (unicap.Provider, string, bool) {
+	return b.provider, taskID, true
+}
+
+// Name returns the wrapped provider's identifier.
+func (b *Breaker) Name() string {
+	return b.provider.Name()
+}
+
+// State returns the current circuit state.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.config.Cooldown {
+		return BreakerHalfOpen
+	}
+
+	return b.state
+}
+
+// allow reports whether a call may proceed, moving an open circuit to
+// half-open once the cooldown has elapsed. It reports whether the call is the
+// half-open trial.
+func (b *Breaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.config.Cooldown {
+		b.state = BreakerHalfOpen
+	}
+
+	switch b.state {
+	case BreakerOpen:
+		return false, fmt.Errorf("%s: circuit open: %w", b.provider.Name(), unicap.ErrProviderUnavailable)
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, fmt.Errorf("%s: circuit half-open: %w", b.provider.Name(), unicap.ErrProviderUnavailable)
+		}
+
+		b.probing = true
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// record updates the circuit with the outcome of a call.
+func (b *Breaker) record(ctx context.Context, probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	if err != nil && ctx.Err() != nil {
+		// A call cut short by its caller says nothing about provider health;
+		// leave a half-open circuit waiting for the next trial.
+		return
+	}
+
+	switch {
+	case err == nil || !countsAsFailure(err):
+		b.state = BreakerClosed
+		b.failures = 0
+	case isFatal(err):
+		b.open()
+	default:
+		b.failures++
+		if probe || b.failures >= b.config.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *Breaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+}
+
+// isFatal reports whether err means the provider cannot serve any task until
+// an operator intervenes.
+func isFatal(err error) bool {
+	return errors.Is(err, unicap.ErrInvalidAPIKey) || errors.Is(err, unicap.ErrInsufficientFunds)
+}
+
+// countsAsFailure reports whether err reflects provider health: fatal account
+// errors, retriable provider errors, and transport failures including HTTP
+// timeouts. Task-level errors such as invalid or unsupported tasks do not
+// count.
+func countsAsFailure(err error) bool {
+	if isFatal(err) {
+		return true
+	}
+
+	if perr, ok := errors.AsType[*unicap.Error](err); ok {
+		return perr.Retriable
+	}
+
+	return !errors.Is(err, unicap.ErrInvalidTask) &&
+		!errors.Is(err, unicap.ErrUnsupportedTask) &&
+		!errors.Is(err, unicap.ErrTaskNotFound)
+}
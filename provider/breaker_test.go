@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	fake := &fakeProvider{name: "fake", createErr: errors.New("connection refused")}
+
+	breaker, err := NewBreaker(fake, BreakerConfig{FailureThreshold: 2, Cooldown: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewBreaker: %v", err)
+	}
+
+	task := &tasks.TextCaptchaTask{Question: "q"}
+
+	for range 2 {
+		if _, err := breaker.CreateTask(t.Context(), task); errors.Is(err, unicap.ErrProviderUnavailable) {
+			t.Fatalf("circuit opened before threshold: %v", err)
+		}
+	}
+
+	if got := breaker.State(); got != BreakerOpen {
+		t.Fatalf("State() = %v, want %v", got, BreakerOpen)
+	}
+
+	if _, err := breaker.CreateTask(t.Context(), task); !errors.Is(err, unicap.ErrProviderUnavailable) {
+		t.Fatalf("errors.Is(%v, ErrProviderUnavailable) = false, want true", err)
+	}
+
+	if fake.attempts != 2 {
+		t.Errorf("provider called %d times, want 2", fake.attempts)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if got := breaker.State(); got != BreakerHalfOpen {
+		t.Fatalf("State() = %v, want %v", got, BreakerHalfOpen)
+	}
+
+	fake.mu.Lock()
+	fake.createErr = nil
+	fake.mu.Unlock()
+
+	if _, err := breaker.CreateTask(t.Context(), task); err != nil {
+		t.Fatalf("trial call: %v", err)
+	}
+
+	if got := breaker.State(); got != BreakerClosed {
+		t.Errorf("State() = %v, want %v", got, BreakerClosed)
+	}
+}
+
+func TestBreakerFailureClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantState BreakerState
+	}{
+		{
+			name:      "insufficient funds opens immediately",
+			err:       unicap.NewError("ERROR_ZERO_BALANCE", "empty", "fake", false, unicap.ErrInsufficientFunds),
+			wantState: BreakerOpen,
+		},
+		{
+			name:      "invalid key opens immediately",
+			err:       unicap.NewError("ERROR_KEY_DOES_NOT_EXIST", "bad key", "fake", false, unicap.ErrInvalidAPIKey),
+			wantState: BreakerOpen,
+		},
+		{
+			name:      "retriable errors reach threshold",
+			err:       unicap.NewError("ERROR_SERVICE", "down", "fake", true, nil),
+			wantState: BreakerOpen,
+		},
+		{
+			name:      "invalid task does not count",
+			err:       unicap.NewError("ERROR_WRONG_TASK_DATA", "bad", "fake", false, unicap.ErrInvalidTask),
+			wantState: BreakerClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker, err := NewBreaker(&fakeProvider{name: "fake", createErr: tt.err}, BreakerConfig{FailureThreshold: 2})
+			if err != nil {
+				t.Fatalf("NewBreaker: %v", err)
+			}
+
+			for range 2 {
+				_, _ = breaker.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+			}
+
+			if got := breaker.State(); got != tt.wantState {
+				t.Errorf("State() = %v, want %v", got, tt.wantState)
+			}
+		})
+	}
+}
@@ -48,5 +48,15 @@ func New(apiKey string, opts ...Option) (unicap.Provider, error) {
 		[]string{"ERROR_INVALID_TASK_DATA"},
 	)
 
+	opts = append([]Option{solverapi.WithReportMapper(mapReport)}, opts...)
+
 	return solverapi.New(name, baseURL, apiKey, mapTask, errs, opts...), nil
 }
+
+// mapReport routes solution feedback to the feedbackTask endpoint, which
+// accepts any task type.
+func mapReport(_ unicap.TaskType, correct bool) (string, map[string]any, error) {
+	return "/feedbackTask", map[string]any{
+		"result": map[string]any{"invalid": !correct},
+	}, nil
+}
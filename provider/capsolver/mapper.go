@@ -112,6 +112,22 @@ type imageToTextTask struct {
 	Module     string `json:"module,omitempty"`
 }
 
+type gridClassificationTask struct {
+	Type     string   `json:"type"`
+	Image    string   `json:"image,omitempty"`
+	Images   []string `json:"images,omitempty"`
+	Queries  []string `json:"queries,omitempty"`
+	Question string   `json:"question"`
+}
+
+type visionEngineTask struct {
+	Type            string `json:"type"`
+	Module          string `json:"module"`
+	Image           string `json:"image"`
+	ImageBackground string `json:"imageBackground,omitempty"`
+	Question        string `json:"question,omitempty"`
+}
+
 type awsWAFTask struct {
 	Type           string `json:"type"`
 	WebsiteURL     string `json:"websiteURL"`
@@ -157,6 +173,12 @@ func mapTask(task unicap.Task) (any, error) {
 		return mapHCaptcha(t), nil
 	case *tasks.ImageToTextTask:
 		return mapImageToText(t), nil
+	case *tasks.GridClassificationTask:
+		return mapGridClassification(t)
+	case *tasks.RotateTask:
+		return mapRotate(t), nil
+	case *tasks.SliderTask:
+		return mapSlider(t), nil
 	case *tasks.AWSWAFTask:
 		return mapAWSWAF(t), nil
 	case *tasks.MTCaptchaTask:
@@ -377,6 +399,52 @@ func mapImageToText(task *tasks.ImageToTextTask) imageToTextTask {
 	}
 }
 
+// mapGridClassification maps a grid task to the classifier for its kind. The
+// reCAPTCHA classifier takes a single image of the whole grid.
+func mapGridClassification(task *tasks.GridClassificationTask) (gridClassificationTask, error) {
+	result := gridClassificationTask{Question: task.Question}
+
+	switch task.Kind {
+	case tasks.GridKindReCaptcha:
+		if len(task.Images) != 1 {
+			return gridClassificationTask{}, fmt.Errorf("recaptcha grid with %d images: %w", len(task.Images), unicap.ErrUnsupportedTask)
+		}
+
+		result.Type = "ReCaptchaV2Classification"
+		result.Image = task.Images[0]
+	case tasks.GridKindHCaptcha:
+		result.Type = "HCaptchaClassification"
+		result.Queries = task.Images
+	case tasks.GridKindAWSWAF:
+		result.Type = "AwsWafClassification"
+		result.Images = task.Images
+	default:
+		return gridClassificationTask{}, fmt.Errorf("grid kind %q: %w", task.Kind, unicap.ErrUnsupportedTask)
+	}
+
+	return result, nil
+}
+
+func mapRotate(task *tasks.RotateTask) visionEngineTask {
+	return visionEngineTask{
+		Type:            "VisionEngine",
+		Module:          "rotate_1",
+		Image:           task.Body,
+		ImageBackground: task.Background,
+		Question:        task.Instruction,
+	}
+}
+
+func mapSlider(task *tasks.SliderTask) visionEngineTask {
+	return visionEngineTask{
+		Type:            "VisionEngine",
+		Module:          "slider_1",
+		Image:           task.Piece,
+		ImageBackground: task.Background,
+		Question:        task.Instruction,
+	}
+}
+
 func mapAWSWAF(task *tasks.AWSWAFTask) awsWAFTask {
 	result := awsWAFTask{
 		Type:           "AntiAwsWafTaskProxyLess",
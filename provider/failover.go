@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aarock1234/unicap"
+)
+
+var (
+	_ unicap.Provider  = (*failover)(nil)
+	_ unicap.Reporter  = (*failover)(nil)
+	_ unicap.Delegator = (*failover)(nil)
+)
+
+// failover submits tasks to the first provider that accepts them.
+type failover struct {
+	providers []unicap.Provider
+}
+
+// Failover creates a provider that submits each task to the given providers in
+// order, moving on to the next provider when CreateTask fails with an invalid
+// key, insufficient funds, an unsupported task type, an unavailable provider,
+// a retriable provider error, or a transport failure. Task IDs are namespaced
+// so GetTaskResult and reports reach the provider that accepted the task.
+func Failover(providers ...unicap.Provider) (unicap.Provider, error) {
+	if len(providers) == 0 {
+		return nil, unicap.ErrNilProvider
+	}
+
+	for i, p := range providers {
+		if p == nil {
+			return nil, fmt.Errorf("provider %d: %w", i, unicap.ErrNilProvider)
+		}
+	}
+
+	return &failover{providers: providers}, nil
+}
+
+// CreateTask submits the task to each provider in turn until one accepts it.
+// When every provider fails, the returned error joins all of their errors.
+func (f *failover) CreateTask(ctx context.Context, task unicap.Task) (string, error) {
+	var errs []error
+
+	for i, p := range f.providers {
+		taskID, err := p.CreateTask(ctx, task)
+		if err == nil {
+			return joinTaskID(i, taskID), nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+
+		if ctx.Err() != nil || !shouldFailover(err) {
+			break
+		}
+	}
+
+	return "", errors.Join(errs...)
+}
+
+// GetTaskResult retrieves the result from the provider that owns the task.
+func (f *failover) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	index, backendID, err := splitTaskID(taskID, len(f.providers))
+	if err != nil {
+		return nil, err
+	}
+
+	return f.providers[index].GetTaskResult(ctx, backendID)
+}
+
+// ReportIncorrect forwards the report to the provider that owns the task.
+func (f *failover) ReportIncorrect(ctx context.Context, taskID string) error {
+	return reportTo(ctx, f.providers, taskID, false)
+}
+
+// ReportCorrect forwards the report to the provider that owns the task.
+func (f *failover) ReportCorrect(ctx context.Context, taskID string) error {
+	return reportTo(ctx, f.providers, taskID, true)
+}
+
+// Delegate returns the backend that owns the task and its backend task ID.
+func (f *failover) Delegate(taskID string) (unicap.Provider, string, bool) {
+	return delegateTo(f.providers, taskID)
+}
+
+// Name returns the provider identifier.
+func (f *failover) Name() string {
+	return "failover"
+}
+
+// shouldFailover reports whether a CreateTask error means the next provider
+// may succeed where this one did not. Callers check for caller cancellation
+// first, so deadline errors here are transport timeouts.
+func shouldFailover(err error) bool {
+	switch {
+	case errors.Is(err, unicap.ErrInvalidAPIKey),
+		errors.Is(err, unicap.ErrInsufficientFunds),
+		errors.Is(err, unicap.ErrUnsupportedTask),
+		errors.Is(err, unicap.ErrProviderUnavailable):
+		return true
+	case errors.Is(err, unicap.ErrInvalidTask):
+		return false
+	}
+
+	if perr, ok := errors.AsType[*unicap.Error](err); ok {
+		return perr.Retriable
+	}
+
+	// Anything else is a transport or decoding failure, which another provider
+	// is unlikely to share.
+	return true
+}
+
+// reportTo forwards a solution report to the provider that owns a namespaced
+// task ID.
+func reportTo(ctx context.Context, providers []unicap.Provider, taskID string, correct bool) error {
+	index, backendID, err := splitTaskID(taskID, len(providers))
+	if err != nil {
+		return err
+	}
+
+	return report(ctx, providers[index], backendID, correct)
+}
+
+// report forwards a solution report to p, failing with ErrUnsupportedOperation
+// if p does not implement unicap.Reporter.
+func report(ctx context.Context, p unicap.Provider, taskID string, correct bool) error {
+	r, ok := p.(unicap.Reporter)
+	if !ok {
+		return fmt.Errorf("report for %s: %w", p.Name(), unicap.ErrUnsupportedOperation)
+	}
+
+	if correct {
+		return r.ReportCorrect(ctx, taskID)
+	}
+
+	return r.ReportIncorrect(ctx, taskID)
+}
+
+// balance forwards a balance lookup to p, failing with
+// ErrUnsupportedOperation if p does not implement unicap.BalanceProvider.
+func balance(ctx context.Context, p unicap.Provider) (float64, error) {
+	bp, ok := p.(unicap.BalanceProvider)
+	if !ok {
+		return 0, fmt.Errorf("balance for %s: %w", p.Name(), unicap.ErrUnsupportedOperation)
+	}
+
+	return bp.Balance(ctx)
+}
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/provider/capsolver"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+// fakeProvider is a scripted provider that records the calls it receives.
+type fakeProvider struct {
+	name      string
+	createErr error
+	result    *unicap.TaskResult
+
+	mu       sync.Mutex
+	attempts int
+	created  int
+	polled   []string
+	reported []string
+}
+
+func (f *fakeProvider) CreateTask(context.Context, unicap.Task) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts++
+
+	if f.createErr != nil {
+		return "", f.createErr
+	}
+
+	f.created++
+
+	return f.name + "-task", nil
+}
+
+func (f *fakeProvider) GetTaskResult(_ context.Context, taskID string) (*unicap.TaskResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.polled = append(f.polled, taskID)
+
+	if f.result != nil {
+		return f.result, nil
+	}
+
+	return &unicap.TaskResult{
+		Status:   unicap.TaskStatusReady,
+		Solution: unicap.Solution{Token: f.name},
+	}, nil
+}
+
+func (f *fakeProvider) ReportIncorrect(_ context.Context, taskID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reported = append(f.reported, taskID)
+
+	return nil
+}
+
+func (f *fakeProvider) ReportCorrect(context.Context, string) error {
+	return nil
+}
+
+func (f *fakeProvider) Name() string {
+	return f.name
+}
+
+func TestFailoverCreateTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		primaryErr error
+		wantOwner  string
+		wantErrIs  error
+	}{
+		{
+			name:      "primary succeeds",
+			wantOwner: "primary",
+		},
+		{
+			name:       "insufficient funds fails over",
+			primaryErr: unicap.NewError("ERROR_ZERO_BALANCE", "empty", "primary", false, unicap.ErrInsufficientFunds),
+			wantOwner:  "secondary",
+		},
+		{
+			name:       "unsupported task fails over",
+			primaryErr: unicap.ErrUnsupportedTask,
+			wantOwner:  "secondary",
+		},
+		{
+			name:       "retriable error fails over",
+			primaryErr: unicap.NewError("ERROR_SERVICE", "down", "primary", true, nil),
+			wantOwner:  "secondary",
+		},
+		{
+			name:       "invalid task does not fail over",
+			primaryErr: unicap.NewError("ERROR_BAD", "bad", "primary", false, unicap.ErrInvalidTask),
+			wantErrIs:  unicap.ErrInvalidTask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeProvider{name: "primary", createErr: tt.primaryErr}
+			secondary := &fakeProvider{name: "secondary"}
+
+			p, err := Failover(primary, secondary)
+			if err != nil {
+				t.Fatalf("Failover: %v", err)
+			}
+
+			taskID, err := p.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				if secondary.created != 0 {
+					t.Errorf("secondary created %d tasks, want 0", secondary.created)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+
+			result, err := p.GetTaskResult(t.Context(), taskID)
+			if err != nil {
+				t.Fatalf("GetTaskResult: %v", err)
+			}
+
+			if result.Solution.Token != tt.wantOwner {
+				t.Errorf("result from %q, want %q", result.Solution.Token, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestFailoverAllFail(t *testing.T) {
+	p, err := Failover(
+		&fakeProvider{name: "a", createErr: unicap.ErrInvalidAPIKey},
+		&fakeProvider{name: "b", createErr: unicap.ErrInsufficientFunds},
+	)
+	if err != nil {
+		t.Fatalf("Failover: %v", err)
+	}
+
+	_, err = p.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+
+	if !errors.Is(err, unicap.ErrInvalidAPIKey) || !errors.Is(err, unicap.ErrInsufficientFunds) {
+		t.Fatalf("error %v does not wrap every provider error", err)
+	}
+}
+
+func TestFailoverRoutesReports(t *testing.T) {
+	primary := &fakeProvider{name: "primary", createErr: unicap.ErrInvalidAPIKey}
+	secondary := &fakeProvider{name: "secondary"}
+
+	p, err := Failover(primary, secondary)
+	if err != nil {
+		t.Fatalf("Failover: %v", err)
+	}
+
+	taskID, err := p.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	if err := p.(unicap.Reporter).ReportIncorrect(t.Context(), taskID); err != nil {
+		t.Fatalf("ReportIncorrect: %v", err)
+	}
+
+	if len(secondary.reported) != 1 || secondary.reported[0] != "secondary-task" {
+		t.Errorf("secondary reports = %v, want [secondary-task]", secondary.reported)
+	}
+
+	if _, err := p.GetTaskResult(t.Context(), "bogus"); !errors.Is(err, unicap.ErrTaskNotFound) {
+		t.Errorf("errors.Is(%v, ErrTaskNotFound) = false, want true", err)
+	}
+}
+
+func TestFailoverCallback(t *testing.T) {
+	receiver := unicap.NewCallbackReceiver()
+	callbacks := httptest.NewServer(receiver)
+	t.Cleanup(callbacks.Close)
+
+	var (
+		polls  atomic.Int32
+		solved = make(chan struct{})
+	)
+
+	// The backend solves the task and then posts the callback, which carries
+	// its own task ID rather than the failover's.
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/createTask":
+			var body struct {
+				CallbackURL string `json:"callbackUrl"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding createTask: %v", err)
+			}
+
+			go func() {
+				close(solved)
+
+				resp, err := http.Post(body.CallbackURL, "application/json", strings.NewReader(`{"taskId":"123","status":"ready"}`))
+				if err != nil {
+					t.Errorf("posting callback: %v", err)
+
+					return
+				}
+				_ = resp.Body.Close()
+			}()
+
+			_, _ = w.Write([]byte(`{"errorId":0,"taskId":"123"}`))
+		case "/getTaskResult":
+			polls.Add(1)
+
+			select {
+			case <-solved:
+				_, _ = w.Write([]byte(`{"errorId":0,"status":"ready","solution":{"gRecaptchaResponse":"tok"}}`))
+			default:
+				_, _ = w.Write([]byte(`{"errorId":0,"status":"processing"}`))
+			}
+		default:
+			t.Errorf("unexpected request to %q", r.URL.Path)
+		}
+	}))
+	t.Cleanup(backend.Close)
+
+	cs, err := capsolver.New("key", capsolver.WithBaseURL(backend.URL))
+	if err != nil {
+		t.Fatalf("capsolver.New: %v", err)
+	}
+
+	f, err := Failover(cs)
+	if err != nil {
+		t.Fatalf("Failover: %v", err)
+	}
+
+	// Polling would wait far longer than the test allows, so only a matched
+	// callback can finish the solve in time.
+	poller := unicap.NewPoller(f, unicap.PollerConfig{
+		InitialInterval: time.Minute,
+		MaxInterval:     time.Minute,
+		Timeout:         time.Minute,
+		Multiplier:      1,
+	})
+
+	client, err := unicap.New(f,
+		unicap.WithPoller(poller),
+		unicap.WithCallback(receiver, callbacks.URL, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("unicap.New: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	solution, err := client.Solve(ctx, &tasks.ReCaptchaV2Task{WebsiteURL: "https://example.com", WebsiteKey: "key"})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+
+	if solution.Token != "tok" {
+		t.Errorf("Token = %q, want %q", solution.Token, "tok")
+	}
+
+	if solution.Provider != cs.Name() {
+		t.Errorf("Provider = %q, want %q", solution.Provider, cs.Name())
+	}
+
+	if solution.TaskID != "0:123" {
+		t.Errorf("TaskID = %q, want %q", solution.TaskID, "0:123")
+	}
+
+	if got := polls.Load(); got != 1 {
+		t.Errorf("getTaskResult calls = %d, want 1", got)
+	}
+}
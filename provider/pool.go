@@ -0,0 +1,413 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aarock1234/unicap"
+)
+
+var (
+	_ unicap.Provider  = (*Pool)(nil)
+	_ unicap.Reporter  = (*Pool)(nil)
+	_ unicap.Delegator = (*Pool)(nil)
+)
+
+// Pool is a provider that steers each task to the provider with the best
+// recent track record for the task's type. It observes every task it creates
+// through to a terminal GetTaskResult and keeps a rolling window of outcomes
+// per provider and task type. It is safe for concurrent use.
+type Pool struct {
+	providers []unicap.Provider
+	config    PoolConfig
+
+	mu        sync.Mutex
+	windows   map[statsKey]*window
+	inflight  map[string]inflightTask
+	lastSweep time.Time
+}
+
+// PoolConfig defines how a Pool scores and selects providers.
+type PoolConfig struct {
+	// Exploration is the fraction of tasks, between 0 and 1, sent to a
+	// uniformly random provider so that statistics stay fresh.
+	Exploration float64
+
+	// Window is the number of recent outcomes kept per provider and task type.
+	Window int
+
+	// MaxAge bounds how long a created task is tracked while waiting for a
+	// terminal result; tasks that are never polled to completion are dropped
+	// after it.
+	MaxAge time.Duration
+
+	// Score rates a provider from its statistics for a task type. Higher is
+	// better.
+	Score func(Stats) float64
+}
+
+// DefaultPoolConfig returns sensible defaults for a Pool.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		Exploration: 0.1,
+		Window:      100,
+		MaxAge:      10 * time.Minute,
+		Score:       DefaultScore,
+	}
+}
+
+// Stats summarizes a provider's recent outcomes for one task type.
+type Stats struct {
+	Provider string
+	TaskType unicap.TaskType
+
+	// Attempts is the number of outcomes in the window.
+	Attempts int
+
+	// Successes is the number of tasks that reached TaskStatusReady.
+	Successes int
+
+	// P50 and P95 are time-to-ready percentiles of successful tasks.
+	P50 time.Duration
+	P95 time.Duration
+
+	// Failures counts failed outcomes by provider error code. Failures without
+	// a *unicap.Error are counted under the empty code.
+	Failures map[string]int
+}
+
+// SuccessRatio returns the fraction of attempts that succeeded, or zero when
+// there are no attempts.
+func (s Stats) SuccessRatio() float64 {
+	if s.Attempts == 0 {
+		return 0
+	}
+
+	return float64(s.Successes) / float64(s.Attempts)
+}
+
+const (
+	// maxLatencyPenalty is the largest fraction of its score DefaultScore
+	// takes from a provider for being slow.
+	maxLatencyPenalty = 0.25
+
+	// latencyPenaltyScale is the median time-to-ready at which DefaultScore
+	// applies the full latency penalty.
+	latencyPenaltyScale = time.Minute
+)
+
+// DefaultScore rates a provider by its smoothed success ratio, reduced by up
+// to a quarter as its median time-to-ready approaches one minute. Success
+// dominates, so latency only decides between providers of similar
+// reliability. Providers without history score as a coin flip with instant
+// solves, so they are tried early.
+func DefaultScore(s Stats) float64 {
+	ratio := (float64(s.Successes) + 1) / (float64(s.Attempts) + 2)
+	slowness := min(float64(s.P50)/float64(latencyPenaltyScale), 1)
+
+	return ratio * (1 - maxLatencyPenalty*slowness)
+}
+
+type statsKey struct {
+	provider int
+	taskType unicap.TaskType
+}
+
+type inflightTask struct {
+	provider int
+	taskType unicap.TaskType
+	created  time.Time
+}
+
+// outcome is one terminal task result.
+type outcome struct {
+	success bool
+	latency time.Duration
+	code    string
+}
+
+// window is a fixed-size ring of recent outcomes.
+type window struct {
+	outcomes []outcome
+	next     int
+	full     bool
+}
+
+func (w *window) add(o outcome) {
+	w.outcomes[w.next] = o
+	w.next = (w.next + 1) % len(w.outcomes)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+func (w *window) items() []outcome {
+	if w.full {
+		return w.outcomes
+	}
+
+	return w.outcomes[:w.next]
+}
+
+// NewPool creates a Pool over the given providers. Zero-valued config fields
+// fall back to DefaultPoolConfig, except Exploration, which may be zero.
+func NewPool(config PoolConfig, providers ...unicap.Provider) (*Pool, error) {
+	if len(providers) == 0 {
+		return nil, unicap.ErrNilProvider
+	}
+
+	for _, p := range providers {
+		if p == nil {
+			return nil, unicap.ErrNilProvider
+		}
+	}
+
+	defaults := DefaultPoolConfig()
+	if config.Window <= 0 {
+		config.Window = defaults.Window
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = defaults.MaxAge
+	}
+	if config.Score == nil {
+		config.Score = defaults.Score
+	}
+
+	return &Pool{
+		providers: providers,
+		config:    config,
+		windows:   make(map[statsKey]*window),
+		inflight:  make(map[string]inflightTask),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// CreateTask submits the task to the best-scoring provider for its type, or
+// to a random provider with probability Exploration.
+func (p *Pool) CreateTask(ctx context.Context, task unicap.Task) (string, error) {
+	index := p.choose(task.Type())
+
+	taskID, err := p.providers[index].CreateTask(ctx, task)
+	if err != nil {
+		if ctx.Err() == nil && !errors.Is(err, unicap.ErrInvalidTask) {
+			p.record(statsKey{index, task.Type()}, outcome{code: errorCode(err)})
+		}
+
+		return "", err
+	}
+
+	id := joinTaskID(index, taskID)
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inflight[id] = inflightTask{provider: index, taskType: task.Type(), created: now}
+	p.sweep(now)
+
+	return id, nil
+}
+
+// GetTaskResult retrieves the result from the provider that owns the task and
+// records terminal outcomes.
+func (p *Pool) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	index, backendID, err := splitTaskID(taskID, len(p.providers))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.providers[index].GetTaskResult(ctx, backendID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch result.Status {
+	case unicap.TaskStatusReady:
+		p.finish(taskID, func(task inflightTask) outcome {
+			return outcome{success: true, latency: time.Since(task.created)}
+		})
+	case unicap.TaskStatusFailed:
+		p.finish(taskID, func(inflightTask) outcome {
+			var code string
+			if result.Error != nil {
+				code = result.Error.Code
+			}
+
+			return outcome{code: code}
+		})
+	}
+
+	return result, nil
+}
+
+// ReportIncorrect forwards the report to the provider that owns the task.
+func (p *Pool) ReportIncorrect(ctx context.Context, taskID string) error {
+	return reportTo(ctx, p.providers, taskID, false)
+}
+
+// ReportCorrect forwards the report to the provider that owns the task.
+func (p *Pool) ReportCorrect(ctx context.Context, taskID string) error {
+	return reportTo(ctx, p.providers, taskID, true)
+}
+
+// Delegate returns the backend that owns the task and its backend task ID.
+func (p *Pool) Delegate(taskID string) (unicap.Provider, string, bool) {
+	return delegateTo(p.providers, taskID)
+}
+
+// Name returns the provider identifier.
+func (p *Pool) Name() string {
+	return "pool"
+}
+
+// Stats returns the current statistics for every provider and task type the
+// pool has observed.
+func (p *Pool) Stats() []Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := slices.SortedFunc(maps.Keys(p.windows), func(a, b statsKey) int {
+		if c := cmp.Compare(a.taskType, b.taskType); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.provider, b.provider)
+	})
+
+	stats := make([]Stats, 0, len(keys))
+	for _, key := range keys {
+		stats = append(stats, p.statsLocked(key))
+	}
+
+	return stats
+}
+
+// choose picks the provider index for a new task of the given type.
+func (p *Pool) choose(taskType unicap.TaskType) int {
+	if len(p.providers) == 1 {
+		return 0
+	}
+
+	if rand.Float64() < p.config.Exploration {
+		return rand.IntN(len(p.providers))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best, bestScore := 0, -1.0
+	for i := range p.providers {
+		score := p.config.Score(p.statsLocked(statsKey{i, taskType}))
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	return best
+}
+
+// finish records the outcome of a tracked task and stops tracking it.
+func (p *Pool) finish(taskID string, build func(inflightTask) outcome) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	task, ok := p.inflight[taskID]
+	if !ok {
+		return
+	}
+
+	delete(p.inflight, taskID)
+	p.recordLocked(statsKey{task.provider, task.taskType}, build(task))
+}
+
+func (p *Pool) record(key statsKey, o outcome) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.recordLocked(key, o)
+}
+
+func (p *Pool) recordLocked(key statsKey, o outcome) {
+	w, ok := p.windows[key]
+	if !ok {
+		w = &window{outcomes: make([]outcome, p.config.Window)}
+		p.windows[key] = w
+	}
+
+	w.add(o)
+}
+
+// sweep drops tracked tasks older than MaxAge, at most once per MaxAge.
+func (p *Pool) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < p.config.MaxAge {
+		return
+	}
+
+	p.lastSweep = now
+
+	for id, task := range p.inflight {
+		if now.Sub(task.created) > p.config.MaxAge {
+			delete(p.inflight, id)
+		}
+	}
+}
+
+func (p *Pool) statsLocked(key statsKey) Stats {
+	stats := Stats{
+		Provider: p.providers[key.provider].Name(),
+		TaskType: key.taskType,
+		Failures: make(map[string]int),
+	}
+
+	w, ok := p.windows[key]
+	if !ok {
+		return stats
+	}
+
+	var latencies []time.Duration
+	for _, o := range w.items() {
+		stats.Attempts++
+
+		if o.success {
+			stats.Successes++
+			latencies = append(latencies, o.latency)
+
+			continue
+		}
+
+		stats.Failures[o.code]++
+	}
+
+	slices.Sort(latencies)
+	stats.P50 = percentile(latencies, 0.50)
+	stats.P95 = percentile(latencies, 0.95)
+
+	return stats
+}
+
+// percentile returns the q-th quantile of sorted durations using the
+// nearest-rank method, or zero for an empty slice.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(q*float64(len(sorted))+0.5) - 1
+
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// errorCode returns the provider error code carried by err, if any.
+func errorCode(err error) string {
+	if perr, ok := errors.AsType[*unicap.Error](err); ok {
+		return perr.Code
+	}
+
+	return ""
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+func TestPoolPrefersSuccessfulProvider(t *testing.T) {
+	bad := &fakeProvider{name: "bad", result: &unicap.TaskResult{
+		Status: unicap.TaskStatusFailed,
+		Error:  unicap.NewError("ERROR_CAPTCHA_UNSOLVABLE", "unsolvable", "bad", true, nil),
+	}}
+	good := &fakeProvider{name: "good"}
+
+	config := DefaultPoolConfig()
+	config.Exploration = 0
+
+	pool, err := NewPool(config, bad, good)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+
+	task := &tasks.ImageToTextTask{Body: "b"}
+
+	for range 10 {
+		taskID, err := pool.CreateTask(t.Context(), task)
+		if err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+
+		if _, err := pool.GetTaskResult(t.Context(), taskID); err != nil {
+			t.Fatalf("GetTaskResult: %v", err)
+		}
+	}
+
+	if bad.created != 1 {
+		t.Errorf("bad provider created %d tasks, want 1", bad.created)
+	}
+
+	if good.created != 9 {
+		t.Errorf("good provider created %d tasks, want 9", good.created)
+	}
+
+	stats := pool.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("len(Stats()) = %d, want 2", len(stats))
+	}
+
+	if stats[0].Provider != "bad" || stats[0].Failures["ERROR_CAPTCHA_UNSOLVABLE"] != 1 {
+		t.Errorf("bad stats = %+v, want one ERROR_CAPTCHA_UNSOLVABLE failure", stats[0])
+	}
+
+	if stats[1].Provider != "good" || stats[1].SuccessRatio() != 1 || stats[1].Attempts != 9 {
+		t.Errorf("good stats = %+v, want 9 successful attempts", stats[1])
+	}
+}
+
+func TestPoolRecordsCreateFailures(t *testing.T) {
+	broke := &fakeProvider{name: "broke", createErr: unicap.NewError("ERROR_ZERO_BALANCE", "empty", "broke", false, unicap.ErrInsufficientFunds)}
+
+	pool, err := NewPool(DefaultPoolConfig(), broke)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+
+	_, err = pool.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+	if !errors.Is(err, unicap.ErrInsufficientFunds) {
+		t.Fatalf("errors.Is(%v, ErrInsufficientFunds) = false, want true", err)
+	}
+
+	stats := pool.Stats()
+	if len(stats) != 1 || stats[0].Failures["ERROR_ZERO_BALANCE"] != 1 {
+		t.Errorf("Stats() = %+v, want one ERROR_ZERO_BALANCE failure", stats)
+	}
+}
+
+func TestDefaultScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		better Stats
+		worse  Stats
+	}{
+		{
+			name:   "slow but reliable beats fast but failing",
+			better: Stats{Attempts: 10, Successes: 10, P50: 20 * time.Second},
+			worse:  Stats{Attempts: 10, Successes: 3, P50: 5 * time.Second},
+		},
+		{
+			name:   "reliable beats fast failures",
+			better: Stats{Attempts: 10, Successes: 10, P50: 20 * time.Second},
+			worse:  Stats{Attempts: 2, Successes: 0},
+		},
+		{
+			name:   "faster wins at equal reliability",
+			better: Stats{Attempts: 10, Successes: 10, P50: 5 * time.Second},
+			worse:  Stats{Attempts: 10, Successes: 10, P50: 20 * time.Second},
+		},
+		{
+			name:   "untried beats consistently failing",
+			better: Stats{},
+			worse:  Stats{Attempts: 10, Successes: 2, P50: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better, worse := DefaultScore(tt.better), DefaultScore(tt.worse)
+			if better <= worse {
+				t.Errorf("DefaultScore(%+v) = %v, want more than DefaultScore(%+v) = %v", tt.better, better, tt.worse, worse)
+			}
+		})
+	}
+}
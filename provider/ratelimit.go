@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aarock1234/unicap"
+)
+
+var (
+	_ unicap.Provider        = (*RateLimiter)(nil)
+	_ unicap.Reporter        = (*RateLimiter)(nil)
+	_ unicap.BalanceProvider = (*RateLimiter)(nil)
+	_ unicap.Delegator       = (*RateLimiter)(nil)
+)
+
+const (
+	// throttleFloor is the smallest fraction of its configured rate a bucket
+	// shrinks to after repeated throttling.
+	throttleFloor = 0.1
+
+	// siteIdleTimeout is how long a per-site bucket must go unused, and be
+	// full, before it is discarded.
+	siteIdleTimeout = 10 * time.Minute
+)
+
+// Limit is a token-bucket rate limit: Rate requests per second with bursts of
+// up to Burst requests. A zero Rate means unlimited.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitConfig defines the limits a RateLimiter enforces.
+type RateLimitConfig struct {
+	// CreateTask limits task submissions.
+	CreateTask Limit
+
+	// GetTaskResult limits result polls.
+	GetTaskResult Limit
+
+	// PerSite limits task submissions per target site, in addition to
+	// CreateTask.
+	PerSite Limit
+
+	// Site returns the target site of a task for PerSite limits. Defaults to
+	// WebsiteHost.
+	Site func(unicap.Task) string
+}
+
+// RateLimiter is a provider decorator that enforces client-side token-bucket
+// limits on CreateTask and GetTaskResult. Calls wait for a token, honoring
+// context cancellation. When the provider throttles a call (HTTP 429 or
+// ERROR_TOO_MUCH_REQUESTS, surfaced as unicap.ErrRateLimited), the bucket
+// that admitted it halves its rate, down to a tenth of the configured rate,
+// and recovers gradually as calls succeed. It is safe for concurrent use.
+type RateLimiter struct {
+	provider unicap.Provider
+	config   RateLimitConfig
+	create   *bucket
+	poll     *bucket
+
+	mu        sync.Mutex
+	sites     map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter wraps provider with the given limits.
+func NewRateLimiter(provider unicap.Provider, config RateLimitConfig) (*RateLimiter, error) {
+	if provider == nil {
+		return nil, unicap.ErrNilProvider
+	}
+
+	if config.Site == nil {
+		config.Site = WebsiteHost
+	}
+
+	return &RateLimiter{
+		provider:  provider,
+		config:    config,
+		create:    newBucket(config.CreateTask),
+		poll:      newBucket(config.GetTaskResult),
+		sites:     make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// CreateTask waits for the CreateTask and per-site limits, then submits the
+// task.
+func (r *RateLimiter) CreateTask(ctx context.Context, task unicap.Task) (string, error) {
+	site := r.site(task)
+
+	if err := site.wait(ctx); err != nil {
+		return "", err
+	}
+
+	if err := r.create.wait(ctx); err != nil {
+		site.refund()
+
+		return "", err
+	}
+
+	taskID, err := r.provider.CreateTask(ctx, task)
+	site.observe(err)
+	r.create.observe(err)
+
+	return taskID, err
+}
+
+// GetTaskResult waits for the GetTaskResult limit, then retrieves the result.
+func (r *RateLimiter) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	if err := r.poll.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := r.provider.GetTaskResult(ctx, taskID)
+	if err == nil && result.Error != nil {
+		r.poll.observe(result.Error)
+	} else {
+		r.poll.observe(err)
+	}
+
+	return result, err
+}
+
+// ReportIncorrect forwards the report to the wrapped provider.
+func (r *RateLimiter) ReportIncorrect(ctx context.Context, taskID string) error {
+	return report(ctx, r.provider, taskID, false)
+}
+
+// ReportCorrect forwards the report to the wrapped provider.
+func (r *RateLimiter) ReportCorrect(ctx context.Context, taskID string) error {
+	return report(ctx, r.provider, taskID, true)
+}
+
+// Balance returns the wrapped provider's account balance.
+func (r *RateLimiter) Balance(ctx context.Context) (float64, error) {
+	return balance(ctx, r.provider)
+}
+
+// Delegate returns the wrapped provider, which issued the task ID unchanged.
+func (r *RateLimiter) Delegate(taskID string) (unicap.Provider, string, bool) {
+	return r.provider, taskID, true
+}
+
+// Name returns the wrapped provider's identifier.
+func (r *RateLimiter) Name() string {
+	return r.provider.Name()
+}
+
+// site returns the per-site bucket for a task, or a nil (unlimited) bucket
+// when per-site limits are off or the task has no site.
+func (r *RateLimiter) site(task unicap.Task) *bucket {
+	if r.config.PerSite.Rate <= 0 {
+		return nil
+	}
+
+	site := r.config.Site(task)
+	if site == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweepSitesLocked(time.Now())
+
+	b, ok := r.sites[site]
+	if !ok {
+		b = newBucket(r.config.PerSite)
+		r.sites[site] = b
+	}
+
+	return b
+}
+
+// sweepSitesLocked discards per-site buckets that are idle, at most once per
+// siteIdleTimeout, so crawling many hosts does not grow the limiter forever.
+// An idle bucket is full, so a later task for its site starts the same way.
+func (r *RateLimiter) sweepSitesLocked(now time.Time) {
+	if now.Sub(r.lastSweep) < siteIdleTimeout {
+		return
+	}
+
+	r.lastSweep = now
+
+	for site, b := range r.sites {
+		if b.idle(now) {
+			delete(r.sites, site)
+		}
+	}
+}
+
+// WebsiteHost returns the host of a task's WebsiteURL field, or the empty
+// string when the task has no such field. Most built-in tasks carry one.
+func WebsiteHost(task unicap.Task) string {
+	v := reflect.ValueOf(task)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := v.FieldByName("WebsiteURL")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	u, err := url.Parse(field.String())
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+// bucket is a token bucket whose rate shrinks when the provider throttles and
+// recovers as calls succeed. A nil bucket is unlimited.
+type bucket struct {
+	mu     sync.Mutex
+	limit  Limit
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit Limit) *bucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	limit.Burst = max(limit.Burst, 1)
+
+	return &bucket{
+		limit:  limit,
+		rate:   limit.Rate,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// wait reserves a token, blocking until it is available or ctx is done. A
+// cancelled wait returns its token.
+func (b *bucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	b.refill(time.Now())
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.refund()
+
+		return ctx.Err()
+	}
+}
+
+// refund returns a reserved token that was not used.
+func (b *bucket) refund() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	b.refill(time.Now())
+}
+
+// idle reports whether the bucket has gone unused for siteIdleTimeout and has
+// refilled to its burst size.
+func (b *bucket) idle(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elapsed := now.Sub(b.last)
+
+	return elapsed >= siteIdleTimeout && b.tokens+elapsed.Seconds()*b.rate >= float64(b.limit.Burst)
+}
+
+// observe adapts the rate to the outcome of a call: multiplicative decrease
+// on throttling, additive increase on success.
+func (b *bucket) observe(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(time.Now())
+
+	switch {
+	case err == nil:
+		b.rate = min(b.rate+b.limit.Rate/20, b.limit.Rate)
+	case errors.Is(err, unicap.ErrRateLimited):
+		b.rate = max(b.rate/2, b.limit.Rate*throttleFloor)
+	}
+}
+
+// refill adds tokens earned since the last refill, capped at the burst size.
+func (b *bucket) refill(now time.Time) {
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, float64(b.limit.Burst))
+	b.last = now
+}
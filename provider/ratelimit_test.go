@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+func TestRateLimiterSpacesCalls(t *testing.T) {
+	limiter, err := NewRateLimiter(&fakeProvider{name: "fake"}, RateLimitConfig{
+		CreateTask: Limit{Rate: 100, Burst: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+
+	start := time.Now()
+
+	for range 4 {
+		if _, err := limiter.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"}); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	// The first call uses the burst token; the remaining three wait 10ms each.
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("4 calls at 100/s took %v, want at least 25ms", elapsed)
+	}
+}
+
+func TestRateLimiterShrinksOnThrottle(t *testing.T) {
+	throttled := fmt.Errorf("unexpected status code: 429: %w", unicap.ErrRateLimited)
+
+	limiter, err := NewRateLimiter(&fakeProvider{name: "fake", createErr: throttled}, RateLimitConfig{
+		CreateTask: Limit{Rate: 100, Burst: 10},
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+
+	for range 5 {
+		_, _ = limiter.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"})
+	}
+
+	if got := limiter.create.rate; got != 10 {
+		t.Errorf("rate after repeated throttling = %v, want floor of 10", got)
+	}
+}
+
+func TestRateLimiterPerSite(t *testing.T) {
+	limiter, err := NewRateLimiter(&fakeProvider{name: "fake"}, RateLimitConfig{
+		PerSite: Limit{Rate: 0.001, Burst: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+
+	a := &tasks.TurnstileTask{WebsiteURL: "https://a.example/login", WebsiteKey: "k"}
+	b := &tasks.TurnstileTask{WebsiteURL: "https://b.example/login", WebsiteKey: "k"}
+
+	for _, task := range []unicap.Task{a, b} {
+		if _, err := limiter.CreateTask(t.Context(), task); err != nil {
+			t.Fatalf("CreateTask(%s): %v", WebsiteHost(task), err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.CreateTask(ctx, a); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second call for same site: errors.Is(%v, DeadlineExceeded) = false, want true", err)
+	}
+}
+
+func TestRateLimiterRefundsSiteToken(t *testing.T) {
+	limiter, err := NewRateLimiter(&fakeProvider{name: "fake"}, RateLimitConfig{
+		CreateTask: Limit{Rate: 0.001, Burst: 1},
+		PerSite:    Limit{Rate: 0.001, Burst: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+
+	a := &tasks.TurnstileTask{WebsiteURL: "https://a.example/login", WebsiteKey: "k"}
+	b := &tasks.TurnstileTask{WebsiteURL: "https://b.example/login", WebsiteKey: "k"}
+
+	if _, err := limiter.CreateTask(t.Context(), b); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	// The site token for a is reserved, then the CreateTask wait times out.
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.CreateTask(ctx, a); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("errors.Is(%v, DeadlineExceeded) = false, want true", err)
+	}
+
+	site := limiter.site(a)
+	site.mu.Lock()
+	tokens := site.tokens
+	site.mu.Unlock()
+
+	if tokens < 1 {
+		t.Errorf("site tokens after cancelled create wait = %v, want the token refunded", tokens)
+	}
+}
+
+func TestRateLimiterEvictsIdleSites(t *testing.T) {
+	limiter, err := NewRateLimiter(&fakeProvider{name: "fake"}, RateLimitConfig{
+		PerSite: Limit{Rate: 1, Burst: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+
+	for _, host := range []string{"a.example", "b.example"} {
+		task := &tasks.TurnstileTask{WebsiteURL: "https://" + host, WebsiteKey: "k"}
+		if _, err := limiter.CreateTask(t.Context(), task); err != nil {
+			t.Fatalf("CreateTask(%s): %v", host, err)
+		}
+	}
+
+	// Keep b busy by spending its token again just before the sweep.
+	later := time.Now().Add(siteIdleTimeout + time.Second)
+	busy := limiter.sites["b.example"]
+	busy.mu.Lock()
+	busy.last = later
+	busy.tokens = 0
+	busy.mu.Unlock()
+
+	limiter.mu.Lock()
+	limiter.sweepSitesLocked(later)
+	_, keptA := limiter.sites["a.example"]
+	_, keptB := limiter.sites["b.example"]
+	limiter.mu.Unlock()
+
+	if keptA {
+		t.Error("idle site a.example was not evicted")
+	}
+
+	if !keptB {
+		t.Error("busy site b.example was evicted")
+	}
+}
+
+func TestWebsiteHost(t *testing.T) {
+	tests := []struct {
+		name string
+		task unicap.Task
+		want string
+	}{
+		{
+			name: "token task",
+			task: &tasks.ReCaptchaV2Task{WebsiteURL: "https://shop.example:8443/checkout"},
+			want: "shop.example",
+		},
+		{
+			name: "task without website",
+			task: &tasks.TextCaptchaTask{Question: "q"},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WebsiteHost(tt.task); got != tt.want {
+				t.Errorf("WebsiteHost() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,6 @@
 // Package provider contains runtime registry helpers for selecting built-in
-// unicap providers by name.
+// unicap providers by name, and composite providers that combine several
+// providers behind the unicap.Provider interface.
 package provider
 
 import (
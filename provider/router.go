@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/aarock1234/unicap"
+)
+
+var (
+	_ unicap.Provider  = (*router)(nil)
+	_ unicap.Reporter  = (*router)(nil)
+	_ unicap.Delegator = (*router)(nil)
+)
+
+// router dispatches tasks to providers by task type.
+type router struct {
+	providers []unicap.Provider
+	routes    map[unicap.TaskType]int
+	fallback  int
+}
+
+// Router creates a provider that submits each task to the provider routed for
+// its task type, or to fallback when the type has no route. A nil fallback
+// makes unrouted task types fail with ErrUnsupportedTask. Task IDs are
+// namespaced so GetTaskResult and reports reach the provider that accepted the
+// task; namespaces are stable for a given routing table.
+func Router(routes map[unicap.TaskType]unicap.Provider, fallback unicap.Provider) (unicap.Provider, error) {
+	if len(routes) == 0 && fallback == nil {
+		return nil, unicap.ErrNilProvider
+	}
+
+	r := &router{
+		routes:   make(map[unicap.TaskType]int, len(routes)),
+		fallback: -1,
+	}
+
+	if fallback != nil {
+		r.fallback = r.add(fallback)
+	}
+
+	// Assign namespaces in task type order so IDs stay valid across processes
+	// built from the same table.
+	for _, taskType := range slices.Sorted(maps.Keys(routes)) {
+		p := routes[taskType]
+		if p == nil {
+			return nil, fmt.Errorf("route %s: %w", taskType, unicap.ErrNilProvider)
+		}
+
+		r.routes[taskType] = r.add(p)
+	}
+
+	return r, nil
+}
+
+// add appends p to the backends and returns its namespace index.
+func (r *router) add(p unicap.Provider) int {
+	r.providers = append(r.providers, p)
+
+	return len(r.providers) - 1
+}
+
+// CreateTask submits the task to the provider routed for its type.
+func (r *router) CreateTask(ctx context.Context, task unicap.Task) (string, error) {
+	index, ok := r.routes[task.Type()]
+	if !ok {
+		index = r.fallback
+	}
+
+	if index < 0 {
+		return "", fmt.Errorf("no route for %s: %w", task.Type(), unicap.ErrUnsupportedTask)
+	}
+
+	taskID, err := r.providers[index].CreateTask(ctx, task)
+	if err != nil {
+		return "", err
+	}
+
+	return joinTaskID(index, taskID), nil
+}
+
+// GetTaskResult retrieves the result from the provider that owns the task.
+func (r *router) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	index, backendID, err := splitTaskID(taskID, len(r.providers))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.providers[index].GetTaskResult(ctx, backendID)
+}
+
+// ReportIncorrect forwards the report to the provider that owns the task.
+func (r *router) ReportIncorrect(ctx context.Context, taskID string) error {
+	return reportTo(ctx, r.providers, taskID, false)
+}
+
+// ReportCorrect forwards the report to the provider that owns the task.
+func (r *router) ReportCorrect(ctx context.Context, taskID string) error {
+	return reportTo(ctx, r.providers, taskID, true)
+}
+
+// Delegate returns the backend that owns the task and its backend task ID.
+func (r *router) Delegate(taskID string) (unicap.Provider, string, bool) {
+	return delegateTo(r.providers, taskID)
+}
+
+// Name returns the provider identifier.
+func (r *router) Name() string {
+	return "router"
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+func TestRouter(t *testing.T) {
+	capsolver := &fakeProvider{name: "capsolver"}
+	anticaptcha := &fakeProvider{name: "anticaptcha"}
+	twocaptcha := &fakeProvider{name: "2captcha"}
+
+	p, err := Router(map[unicap.TaskType]unicap.Provider{
+		unicap.TaskTypeDataDome:            capsolver,
+		unicap.TaskTypeCloudflareChallenge: capsolver,
+		unicap.TaskTypeImageToText:         anticaptcha,
+	}, twocaptcha)
+	if err != nil {
+		t.Fatalf("Router: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		task      unicap.Task
+		wantOwner string
+	}{
+		{
+			name:      "routed type",
+			task:      &tasks.DataDomeTask{},
+			wantOwner: "capsolver",
+		},
+		{
+			name:      "second routed type",
+			task:      &tasks.ImageToTextTask{},
+			wantOwner: "anticaptcha",
+		},
+		{
+			name:      "unrouted type uses fallback",
+			task:      &tasks.TextCaptchaTask{},
+			wantOwner: "2captcha",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskID, err := p.CreateTask(t.Context(), tt.task)
+			if err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+
+			result, err := p.GetTaskResult(t.Context(), taskID)
+			if err != nil {
+				t.Fatalf("GetTaskResult: %v", err)
+			}
+
+			if result.Solution.Token != tt.wantOwner {
+				t.Errorf("result from %q, want %q", result.Solution.Token, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestRouterNoFallback(t *testing.T) {
+	p, err := Router(map[unicap.TaskType]unicap.Provider{
+		unicap.TaskTypeDataDome: &fakeProvider{name: "capsolver"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Router: %v", err)
+	}
+
+	_, err = p.CreateTask(t.Context(), &tasks.TextCaptchaTask{})
+	if !errors.Is(err, unicap.ErrUnsupportedTask) {
+		t.Fatalf("errors.Is(%v, ErrUnsupportedTask) = false, want true", err)
+	}
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/aarock1234/unicap"
+)
+
+var (
+	_ unicap.Provider        = (*Shadow)(nil)
+	_ unicap.Reporter        = (*Shadow)(nil)
+	_ unicap.BalanceProvider = (*Shadow)(nil)
+	_ unicap.Delegator       = (*Shadow)(nil)
+)
+
+// Shadow is a provider that passes every call through to a primary provider
+// while mirroring a sample of tasks to a candidate provider in the background.
+// The candidate's results are never returned to callers; instead each
+// mirrored task produces a Comparison for offline evaluation. It is safe for
+// concurrent use.
+type Shadow struct {
+	primary   unicap.Provider
+	candidate unicap.Provider
+	poller    *unicap.Poller
+	config    ShadowConfig
+
+	mu      sync.Mutex
+	mirrors map[string]*mirror
+	wg      sync.WaitGroup
+}
+
+// ShadowConfig defines how a Shadow samples and reports mirrored tasks.
+type ShadowConfig struct {
+	// SampleRate is the fraction of tasks, between 0 and 1, mirrored to the
+	// candidate.
+	SampleRate float64
+
+	// Poller configures polling of the candidate. Its Timeout also bounds how
+	// long a comparison waits for the primary to reach a terminal state.
+	// Defaults to unicap.DefaultPollerConfig with unicap.DefaultPollerProfiles.
+	Poller unicap.PollerConfig
+
+	// OnComparison receives one record per mirrored task. It is called from
+	// background goroutines and must be safe for concurrent use.
+	OnComparison func(Comparison)
+}
+
+// Comparison records how the primary and candidate providers fared on the
+// same task.
+type Comparison struct {
+	// TaskID is the primary provider's task ID.
+	TaskID   string
+	TaskType unicap.TaskType
+
+	Primary   ShadowOutcome
+	Candidate ShadowOutcome
+
+	// Match reports whether both providers solved a text or image-to-text
+	// task with the same normalized answer. It is false for other task types.
+	Match bool
+}
+
+// ShadowOutcome is one provider's result for a mirrored task.
+type ShadowOutcome struct {
+	Provider string
+
+	// Done reports whether the provider reached a terminal state. The primary
+	// is not done when callers stopped polling it before the comparison was
+	// emitted.
+	Done bool
+
+	// Success reports whether the task reached TaskStatusReady.
+	Success bool
+
+	// Latency is the time from task creation to the terminal state.
+	Latency time.Duration
+
+	// ErrorCode is the provider error code of a failed task, if any.
+	ErrorCode string
+
+	// Err is the error that ended the task, if any.
+	Err error
+
+	solution unicap.Solution
+}
+
+// mirror tracks one task submitted to both providers.
+type mirror struct {
+	taskType unicap.TaskType
+	created  time.Time
+	once     sync.Once
+	done     chan struct{}
+	primary  ShadowOutcome
+}
+
+// NewShadow creates a Shadow that serves traffic from primary and mirrors
+// sampled tasks to candidate.
+func NewShadow(primary, candidate unicap.Provider, config ShadowConfig) (*Shadow, error) {
+	if primary == nil || candidate == nil {
+		return nil, unicap.ErrNilProvider
+	}
+
+	var opts []unicap.PollerOption
+	if config.Poller == (unicap.PollerConfig{}) {
+		config.Poller = unicap.DefaultPollerConfig()
+		opts = append(opts, unicap.WithPollerProfiles(unicap.DefaultPollerProfiles()))
+	}
+
+	return &Shadow{
+		primary:   primary,
+		candidate: candidate,
+		poller:    unicap.NewPoller(candidate, config.Poller, opts...),
+		config:    config,
+		mirrors:   make(map[string]*mirror),
+	}, nil
+}
+
+// CreateTask submits the task to the primary and, for sampled tasks, mirrors
+// it to the candidate in the background.
+func (s *Shadow) CreateTask(ctx context.Context, task unicap.Task) (string, error) {
+	created := time.Now()
+
+	taskID, err := s.primary.CreateTask(ctx, task)
+	if err != nil {
+		return "", err
+	}
+
+	if rand.Float64() >= s.config.SampleRate {
+		return taskID, nil
+	}
+
+	m := &mirror{
+		taskType: task.Type(),
+		created:  created,
+		done:     make(chan struct{}),
+		primary:  ShadowOutcome{Provider: s.primary.Name()},
+	}
+
+	s.mu.Lock()
+	s.mirrors[taskID] = m
+	s.mu.Unlock()
+
+	// The mirror must outlive the caller's request, which typically ends as
+	// soon as the primary answers.
+	bg := context.WithoutCancel(ctx)
+
+	s.wg.Go(func() {
+		s.compare(bg, taskID, task, m)
+	})
+
+	return taskID, nil
+}
+
+// GetTaskResult retrieves the result from the primary and records terminal
+// outcomes of mirrored tasks.
+func (s *Shadow) GetTaskResult(ctx context.Context, taskID string) (*unicap.TaskResult, error) {
+	result, err := s.primary.GetTaskResult(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status != unicap.TaskStatusReady && result.Status != unicap.TaskStatusFailed {
+		return result, nil
+	}
+
+	s.mu.Lock()
+	m, ok := s.mirrors[taskID]
+	s.mu.Unlock()
+
+	if ok {
+		m.once.Do(func() {
+			m.primary.Done = true
+			m.primary.Latency = time.Since(m.created)
+			m.primary.Success = result.Status == unicap.TaskStatusReady
+			m.primary.solution = result.Solution
+
+			if result.Error != nil {
+				m.primary.Err = result.Error
+				m.primary.ErrorCode = result.Error.Code
+			}
+
+			close(m.done)
+		})
+	}
+
+	return result, nil
+}
+
+// ReportIncorrect forwards the report to the primary.
+func (s *Shadow) ReportIncorrect(ctx context.Context, taskID string) error {
+	return report(ctx, s.primary, taskID, false)
+}
+
+// ReportCorrect forwards the report to the primary.
+func (s *Shadow) ReportCorrect(ctx context.Context, taskID string) error {
+	return report(ctx, s.primary, taskID, true)
+}
+
+// Balance returns the primary's account balance.
+func (s *Shadow) Balance(ctx context.Context) (float64, error) {
+	return balance(ctx, s.primary)
+}
+
+// Delegate returns the primary, which issued the task ID unchanged.
+func (s *Shadow) Delegate(taskID string) (unicap.Provider, string, bool) {
+	return s.primary, taskID, true
+}
+
+// Name returns the primary provider's identifier, since Shadow is transparent
+// to callers.
+func (s *Shadow) Name() string {
+	return s.primary.Name()
+}
+
+// Wait blocks until every in-flight mirrored task has emitted its comparison.
+func (s *Shadow) Wait() {
+	s.wg.Wait()
+}
+
+// compare solves the task with the candidate, waits for the primary's
+// outcome, and emits the comparison.
+func (s *Shadow) compare(ctx context.Context, taskID string, task unicap.Task, m *mirror) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.mirrors, taskID)
+		s.mu.Unlock()
+	}()
+
+	candidate := s.solveCandidate(ctx, task, m.created)
+
+	wait := time.NewTimer(max(s.config.Poller.Timeout-time.Since(m.created), 0))
+	defer wait.Stop()
+
+	select {
+	case <-m.done:
+	case <-wait.C:
+	}
+
+	m.once.Do(func() {
+		// The primary never reached a terminal state in time; seal the
+		// outcome so a late GetTaskResult does not race with the read below.
+		close(m.done)
+	})
+	primary := m.primary
+
+	if s.config.OnComparison == nil {
+		return
+	}
+
+	s.config.OnComparison(Comparison{
+		TaskID:    taskID,
+		TaskType:  m.taskType,
+		Primary:   primary,
+		Candidate: candidate,
+		Match:     answersMatch(m.taskType, primary, candidate),
+	})
+}
+
+// solveCandidate submits the task to the candidate and polls it to a terminal
+// state.
+func (s *Shadow) solveCandidate(ctx context.Context, task unicap.Task, created time.Time) ShadowOutcome {
+	out := ShadowOutcome{Provider: s.candidate.Name()}
+
+	taskID, err := s.candidate.CreateTask(ctx, task)
+	if err == nil {
+		var result *unicap.TaskResult
+		if result, err = s.poller.PollTask(ctx, taskID, task.Type()); err == nil {
+			out.Success = true
+			out.solution = result.Solution
+		}
+	}
+
+	out.Done = true
+	out.Latency = time.Since(created)
+	out.Err = err
+
+	if perr, ok := errors.AsType[*unicap.Error](err); ok {
+		out.ErrorCode = perr.Code
+	}
+
+	return out
+}
+
+// answersMatch reports whether two successful outcomes of a text-answer task
+// agree after normalization.
+func answersMatch(taskType unicap.TaskType, a, b ShadowOutcome) bool {
+	if taskType != unicap.TaskTypeImageToText && taskType != unicap.TaskTypeText {
+		return false
+	}
+
+	if !a.Success || !b.Success {
+		return false
+	}
+
+	return unicap.NormalizeAnswer(a.solution.Text) == unicap.NormalizeAnswer(b.solution.Text)
+}
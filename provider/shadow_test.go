@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+func TestShadowMirrorsTasks(t *testing.T) {
+	tests := []struct {
+		name          string
+		candidateText string
+		wantMatch     bool
+	}{
+		{
+			name:          "matching answers",
+			candidateText: " abc ",
+			wantMatch:     true,
+		},
+		{
+			name:          "different answers",
+			candidateText: "xyz",
+			wantMatch:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeProvider{name: "primary", result: &unicap.TaskResult{
+				Status:   unicap.TaskStatusReady,
+				Solution: unicap.Solution{Text: "abc"},
+			}}
+			candidate := &fakeProvider{name: "candidate", result: &unicap.TaskResult{
+				Status:   unicap.TaskStatusReady,
+				Solution: unicap.Solution{Text: tt.candidateText},
+			}}
+
+			var (
+				mu          sync.Mutex
+				comparisons []Comparison
+			)
+
+			shadow, err := NewShadow(primary, candidate, ShadowConfig{
+				SampleRate: 1,
+				Poller: unicap.PollerConfig{
+					InitialInterval: time.Millisecond,
+					MaxInterval:     time.Millisecond,
+					Timeout:         time.Second,
+					Multiplier:      1,
+				},
+				OnComparison: func(c Comparison) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					comparisons = append(comparisons, c)
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewShadow: %v", err)
+			}
+
+			taskID, err := shadow.CreateTask(t.Context(), &tasks.ImageToTextTask{Body: "b"})
+			if err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+
+			result, err := shadow.GetTaskResult(t.Context(), taskID)
+			if err != nil {
+				t.Fatalf("GetTaskResult: %v", err)
+			}
+
+			if result.Solution.Text != "abc" {
+				t.Errorf("text = %q, want primary answer abc", result.Solution.Text)
+			}
+
+			shadow.Wait()
+
+			if len(comparisons) != 1 {
+				t.Fatalf("got %d comparisons, want 1", len(comparisons))
+			}
+
+			c := comparisons[0]
+			if c.TaskID != taskID || c.Primary.Provider != "primary" || c.Candidate.Provider != "candidate" {
+				t.Errorf("comparison = %+v, want primary task %s", c, taskID)
+			}
+
+			if !c.Primary.Done || !c.Primary.Success || !c.Candidate.Success {
+				t.Errorf("outcomes = %+v / %+v, want both successful", c.Primary, c.Candidate)
+			}
+
+			if c.Match != tt.wantMatch {
+				t.Errorf("Match = %v, want %v", c.Match, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestShadowSampleRateZero(t *testing.T) {
+	primary := &fakeProvider{name: "primary"}
+	candidate := &fakeProvider{name: "candidate"}
+
+	shadow, err := NewShadow(primary, candidate, ShadowConfig{})
+	if err != nil {
+		t.Fatalf("NewShadow: %v", err)
+	}
+
+	if _, err := shadow.CreateTask(t.Context(), &tasks.TextCaptchaTask{Question: "q"}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	shadow.Wait()
+
+	if candidate.created != 0 {
+		t.Errorf("candidate created %d tasks, want 0", candidate.created)
+	}
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aarock1234/unicap"
+)
+
+// joinTaskID namespaces a backend task ID with the index of the provider that
+// issued it, so composite providers can route follow-up calls statelessly.
+func joinTaskID(index int, taskID string) string {
+	return strconv.Itoa(index) + ":" + taskID
+}
+
+// splitTaskID reverses joinTaskID. It reports ErrTaskNotFound when the ID was
+// not issued by a composite provider with n backends.
+func splitTaskID(id string, n int) (int, string, error) {
+	prefix, taskID, ok := strings.Cut(id, ":")
+	if !ok {
+		return 0, "", fmt.Errorf("task %s: %w", id, unicap.ErrTaskNotFound)
+	}
+
+	index, err := strconv.Atoi(prefix)
+	if err != nil || index < 0 || index >= n {
+		return 0, "", fmt.Errorf("task %s: %w", id, unicap.ErrTaskNotFound)
+	}
+
+	return index, taskID, nil
+}
+
+// delegateTo resolves a namespaced task ID to the backend that issued it and
+// its backend task ID, for composites implementing unicap.Delegator.
+func delegateTo(providers []unicap.Provider, id string) (unicap.Provider, string, bool) {
+	index, taskID, err := splitTaskID(id, len(providers))
+	if err != nil {
+		return nil, "", false
+	}
+
+	return providers[index], taskID, true
+}
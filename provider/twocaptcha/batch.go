@@ -0,0 +1,126 @@
+package twocaptcha
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/internal/solverapi"
+)
+
+const (
+	legacyURL = "https://2captcha.com"
+
+	// legacyResultPath is the legacy endpoint that accepts a comma-separated
+	// list of task IDs via action=get&ids=.
+	legacyResultPath = "/res.php"
+
+	// legacyMaxTasks is the most task IDs res.php accepts per request.
+	legacyMaxTasks = 100
+
+	// legacyNotReady is the answer res.php returns for unfinished tasks.
+	legacyNotReady = "CAPCHA_NOT_READY"
+)
+
+// WithLegacyBaseURL sets a custom base URL for the legacy res.php endpoint
+// used to check several tasks at once. Intended for testing.
+func WithLegacyBaseURL(u string) Option {
+	return solverapi.WithBatchURL(u + legacyResultPath)
+}
+
+// batchMapper checks tasks through the legacy res.php endpoint, which returns
+// one plain answer per task, joined with "|". Only tasks whose whole solution
+// is a single token are batched: free-text answers may contain "|" themselves,
+// which would shift every answer after them.
+func batchMapper(errs *solverapi.ErrorMapper) solverapi.BatchMapper {
+	return solverapi.BatchMapper{
+		URL:      legacyURL + legacyResultPath,
+		MaxTasks: legacyMaxTasks,
+		Supports: legacySupported,
+		Query: func(apiKey string, taskIDs []string) url.Values {
+			return url.Values{
+				"key":    {apiKey},
+				"action": {"get"},
+				"ids":    {strings.Join(taskIDs, ",")},
+				"json":   {"1"},
+			}
+		},
+		Decode: func(body []byte, taskTypes []unicap.TaskType) ([]*unicap.TaskResult, error) {
+			return decodeLegacyResults(body, taskTypes, errs)
+		},
+	}
+}
+
+// legacySupported reports whether res.php returns the full solution for the
+// task type as a single token that cannot contain "|". FunCaptcha tokens are
+// themselves pipe-separated, so they are not batched.
+func legacySupported(taskType unicap.TaskType) bool {
+	switch taskType {
+	case unicap.TaskTypeReCaptchaV2, unicap.TaskTypeReCaptchaV2Enterprise,
+		unicap.TaskTypeReCaptchaV3, unicap.TaskTypeReCaptchaV3Enterprise:
+		return true
+	default:
+		return false
+	}
+}
+
+// legacyResponse is the res.php JSON envelope. Request holds the
+// pipe-separated answers on success and the error code on failure.
+type legacyResponse struct {
+	Status  int    `json:"status"`
+	Request string `json:"request"`
+}
+
+// decodeLegacyResults maps a multi-ID res.php response to task results.
+func decodeLegacyResults(body []byte, taskTypes []unicap.TaskType, errs *solverapi.ErrorMapper) ([]*unicap.TaskResult, error) {
+	var resp legacyResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshaling response: %w", err)
+	}
+
+	answers := strings.Split(resp.Request, "|")
+	if resp.Status != 1 && !allNotReady(answers) {
+		return nil, errs.Error(resp.Request, "")
+	}
+
+	if len(answers) != len(taskTypes) {
+		return nil, fmt.Errorf("got %d answers for %d tasks", len(answers), len(taskTypes))
+	}
+
+	results := make([]*unicap.TaskResult, len(answers))
+	for i, answer := range answers {
+		switch {
+		case answer == legacyNotReady:
+			results[i] = &unicap.TaskResult{Status: unicap.TaskStatusProcessing}
+		case strings.HasPrefix(answer, "ERROR_"):
+			results[i] = &unicap.TaskResult{
+				Status: unicap.TaskStatusFailed,
+				Error:  errs.Error(answer, ""),
+			}
+		default:
+			results[i] = &unicap.TaskResult{
+				Status: unicap.TaskStatusReady,
+				Solution: unicap.Solution{
+					Token: answer,
+					Extra: map[string]any{"token": answer},
+				},
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// allNotReady reports whether every answer is CAPCHA_NOT_READY. res.php
+// answers with status 0 when no task in the batch has finished yet.
+func allNotReady(answers []string) bool {
+	for _, answer := range answers {
+		if answer != legacyNotReady {
+			return false
+		}
+	}
+
+	return true
+}
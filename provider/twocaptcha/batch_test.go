@@ -0,0 +1,182 @@
+package twocaptcha
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aarock1234/unicap"
+	"github.com/aarock1234/unicap/tasks"
+)
+
+func TestGetTaskResults(t *testing.T) {
+	var (
+		created atomic.Int32
+		gotIDs  string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/createTask":
+			_, _ = fmt.Fprintf(w, `{"errorId":0,"taskId":%d}`, created.Add(1))
+		case "/res.php":
+			q := r.URL.Query()
+			if q.Get("key") != "key" || q.Get("action") != "get" || q.Get("json") != "1" {
+				t.Errorf("query = %v", q)
+			}
+
+			gotIDs = q.Get("ids")
+			_, _ = w.Write([]byte(`{"status":1,"request":"abc|CAPCHA_NOT_READY|ERROR_CAPTCHA_UNSOLVABLE"}`))
+		default:
+			t.Errorf("unexpected request to %q", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := New("key", WithBaseURL(server.URL), WithLegacyBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	bp := p.(unicap.BatchProvider)
+
+	recaptchaID, err := p.CreateTask(t.Context(), &tasks.ReCaptchaV2Task{WebsiteURL: "https://example.com", WebsiteKey: "key"})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	imageID, err := p.CreateTask(t.Context(), &tasks.ImageToTextTask{Body: "aGVsbG8="})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	geetestID, err := p.CreateTask(t.Context(), &tasks.GeeTestTask{WebsiteURL: "https://example.com", GT: "gt", Challenge: "challenge"})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	batchable := map[string]bool{recaptchaID: true, imageID: false, geetestID: false, "unknown": false}
+	for taskID, want := range batchable {
+		if got := bp.Batchable(taskID); got != want {
+			t.Errorf("Batchable(%q) = %v, want %v", taskID, got, want)
+		}
+	}
+
+	results, err := bp.GetTaskResults(t.Context(), []string{recaptchaID, "7", "8"})
+	if err != nil {
+		t.Fatalf("GetTaskResults: %v", err)
+	}
+
+	if gotIDs != "1,7,8" {
+		t.Errorf("ids = %q, want %q", gotIDs, "1,7,8")
+	}
+
+	if results[0].Status != unicap.TaskStatusReady || results[0].Solution.Token != "abc" {
+		t.Errorf("results[0] = %+v, want ready with token abc", results[0])
+	}
+
+	if results[1].Status != unicap.TaskStatusProcessing {
+		t.Errorf("results[1].Status = %v, want processing", results[1].Status)
+	}
+
+	if results[2].Status != unicap.TaskStatusFailed || results[2].Error == nil || results[2].Error.Code != "ERROR_CAPTCHA_UNSOLVABLE" {
+		t.Errorf("results[2] = %+v, want failed with ERROR_CAPTCHA_UNSOLVABLE", results[2])
+	}
+}
+
+func TestDecodeLegacyResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		types     []unicap.TaskType
+		wantToken string
+		wantReady bool
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name:      "token answer",
+			body:      `{"status":1,"request":"tok"}`,
+			types:     []unicap.TaskType{unicap.TaskTypeReCaptchaV2},
+			wantToken: "tok",
+			wantReady: true,
+		},
+		{
+			name:    "answer count mismatch",
+			body:    `{"status":1,"request":"a|b"}`,
+			types:   []unicap.TaskType{unicap.TaskTypeReCaptchaV2},
+			wantErr: true,
+		},
+		{
+			name:  "none ready",
+			body:  `{"status":0,"request":"CAPCHA_NOT_READY|CAPCHA_NOT_READY"}`,
+			types: []unicap.TaskType{unicap.TaskTypeReCaptchaV2, unicap.TaskTypeReCaptchaV3},
+		},
+		{
+			// FunCaptcha tokens are pipe-separated, so a batch holding one
+			// cannot be split; legacySupported keeps them out of batches.
+			name:    "pipe-bearing funcaptcha token",
+			body:    `{"status":1,"request":"03AGdBq2|1717d8a4e2a5c1b03.5204501|r=us-east-1|meta=3|metabgclr=transparent|pk=69A21A01-CC7B-B9C6-0F9A-E7FA06677FFC|at=40|sup=1|rid=38|ag=101|cdn_url=https%3A%2F%2Fclient-api.arkoselabs.com%2Fcdn%2Ffc|lurl=https%3A%2F%2Faudio-us-east-1.arkoselabs.com|surl=https%3A%2F%2Fclient-api.arkoselabs.com"}`,
+			types:   []unicap.TaskType{unicap.TaskTypeReCaptchaV2, unicap.TaskTypeFunCaptcha},
+			wantErr: true,
+		},
+		{
+			name:      "request error",
+			body:      `{"status":0,"request":"ERROR_WRONG_USER_KEY"}`,
+			types:     []unicap.TaskType{unicap.TaskTypeReCaptchaV2},
+			wantErr:   true,
+			wantErrIs: unicap.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := decodeLegacyResults([]byte(tt.body), tt.types, errorMapper())
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != len(tt.types) {
+				t.Fatalf("len(results) = %d, want %d", len(results), len(tt.types))
+			}
+
+			if !tt.wantReady {
+				for i, result := range results {
+					if result.Status != unicap.TaskStatusProcessing {
+						t.Errorf("results[%d].Status = %v, want processing", i, result.Status)
+					}
+				}
+
+				return
+			}
+
+			if got := results[0].Solution.Token; got != tt.wantToken {
+				t.Errorf("Token = %q, want %q", got, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestLegacySupportedSkipsPipeBearingAnswers(t *testing.T) {
+	for _, taskType := range []unicap.TaskType{unicap.TaskTypeImageToText, unicap.TaskTypeText, unicap.TaskTypeFunCaptcha} {
+		if legacySupported(taskType) {
+			t.Errorf("legacySupported(%v) = true, want false", taskType)
+		}
+	}
+}
@@ -40,13 +40,34 @@ func New(apiKey string, opts ...Option) (unicap.Provider, error) {
 		return nil, fmt.Errorf("api key: %w", unicap.ErrInvalidAPIKey)
 	}
 
-	errs := solverapi.StandardErrorMapper(
+	errs := errorMapper()
+
+	opts = append([]Option{
+		solverapi.WithReportMapper(mapReport),
+		solverapi.WithBatchMapper(batchMapper(errs)),
+	}, opts...)
+
+	return solverapi.New(name, baseURL, apiKey, mapTask, errs, opts...), nil
+}
+
+// errorMapper maps 2Captcha error codes, including those of the legacy
+// res.php endpoint, to unicap sentinel errors.
+func errorMapper() *solverapi.ErrorMapper {
+	return solverapi.StandardErrorMapper(
 		name,
 		[]string{"ERROR_KEY_DOES_NOT_EXIST", "ERROR_WRONG_USER_KEY"},
 		[]string{"ERROR_ZERO_BALANCE"},
-		[]string{"ERROR_TASK_ABSENT"},
+		[]string{"ERROR_TASK_ABSENT", "ERROR_WRONG_CAPTCHA_ID"},
 		[]string{"ERROR_WRONG_TASK_DATA"},
 	)
+}
 
-	return solverapi.New(name, baseURL, apiKey, mapTask, errs, opts...), nil
+// mapReport routes solution feedback to the reportCorrect and reportIncorrect
+// endpoints, which accept any task type.
+func mapReport(_ unicap.TaskType, correct bool) (string, map[string]any, error) {
+	if correct {
+		return "/reportCorrect", nil, nil
+	}
+
+	return "/reportIncorrect", nil, nil
 }
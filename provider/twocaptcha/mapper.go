@@ -124,6 +124,36 @@ type imageToTextTask struct {
 	ImgInstructions string `json:"imgInstructions,omitempty"`
 }
 
+type coordinatesTask struct {
+	Type            string `json:"type"`
+	Body            string `json:"body"`
+	Comment         string `json:"comment,omitempty"`
+	ImgInstructions string `json:"imgInstructions,omitempty"`
+	MaxClicks       int    `json:"maxClicks,omitempty"`
+}
+
+type gridTask struct {
+	Type    string `json:"type"`
+	Body    string `json:"body"`
+	Comment string `json:"comment"`
+	Rows    int    `json:"rows,omitempty"`
+	Columns int    `json:"columns,omitempty"`
+}
+
+type audioTask struct {
+	Type string `json:"type"`
+	Body string `json:"body"`
+	Lang string `json:"lang"`
+}
+
+type rotateTask struct {
+	Type            string `json:"type"`
+	Body            string `json:"body"`
+	Angle           int    `json:"angle,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	ImgInstructions string `json:"imgInstructions,omitempty"`
+}
+
 type amazonTask struct {
 	Type            string `json:"type"`
 	WebsiteURL      string `json:"websiteURL"`
@@ -212,6 +242,16 @@ func mapTask(task unicap.Task) (any, error) {
 		return mapHCaptcha(t), nil
 	case *tasks.ImageToTextTask:
 		return mapImageToText(t), nil
+	case *tasks.ImageCoordinatesTask:
+		return mapImageCoordinates(t), nil
+	case *tasks.GridClassificationTask:
+		return mapGrid(t)
+	case *tasks.AudioTask:
+		return mapAudio(t), nil
+	case *tasks.RotateTask:
+		return mapRotate(t)
+	case *tasks.SliderTask:
+		return mapSlider(t), nil
 	case *tasks.AWSWAFTask:
 		return mapAWSWAF(t), nil
 	case *tasks.MTCaptchaTask:
@@ -426,6 +466,81 @@ func mapImageToText(task *tasks.ImageToTextTask) imageToTextTask {
 	}
 }
 
+func mapImageCoordinates(task *tasks.ImageCoordinatesTask) coordinatesTask {
+	return coordinatesTask{
+		Type:            "CoordinatesTask",
+		Body:            task.Body,
+		Comment:         task.Instruction,
+		ImgInstructions: task.InstructionImage,
+		MaxClicks:       task.MaxClicks,
+	}
+}
+
+// mapGrid maps a grid task to GridTask, which takes a single image of the
+// whole grid.
+func mapGrid(task *tasks.GridClassificationTask) (gridTask, error) {
+	if len(task.Images) != 1 {
+		return gridTask{}, fmt.Errorf("grid with %d images: %w", len(task.Images), unicap.ErrUnsupportedTask)
+	}
+
+	return gridTask{
+		Type:    "GridTask",
+		Body:    task.Images[0],
+		Comment: task.Question,
+		Rows:    task.Rows,
+		Columns: task.Columns,
+	}, nil
+}
+
+func mapAudio(task *tasks.AudioTask) audioTask {
+	lang := task.Language
+	if lang == "" {
+		lang = "en"
+	}
+
+	return audioTask{
+		Type: "AudioTask",
+		Body: task.Body,
+		Lang: lang,
+	}
+}
+
+// mapRotate maps a rotate puzzle to RotateTask, which rotates a single image.
+func mapRotate(task *tasks.RotateTask) (rotateTask, error) {
+	if task.Background != "" {
+		return rotateTask{}, fmt.Errorf("rotate with background: %w", unicap.ErrUnsupportedTask)
+	}
+
+	return rotateTask{
+		Type:    "RotateTask",
+		Body:    task.Body,
+		Angle:   task.AngleStep,
+		Comment: task.Instruction,
+	}, nil
+}
+
+// sliderComment tells 2Captcha workers where to click, so the x coordinate
+// they return is the gap's left edge that SliderSolution.GapX promises.
+const sliderComment = "Click the left edge of the gap the piece fits into."
+
+// mapSlider maps a slider puzzle to CoordinatesTask: the worker clicks the gap
+// in the background, shown the piece as the instruction image. The task's
+// instruction is appended to the fixed click instruction.
+func mapSlider(task *tasks.SliderTask) coordinatesTask {
+	comment := sliderComment
+	if task.Instruction != "" {
+		comment += " " + task.Instruction
+	}
+
+	return coordinatesTask{
+		Type:            "CoordinatesTask",
+		Body:            task.Background,
+		Comment:         comment,
+		ImgInstructions: task.Piece,
+		MaxClicks:       1,
+	}
+}
+
 func mapAWSWAF(task *tasks.AWSWAFTask) amazonTask {
 	result := amazonTask{
 		Type:            "AmazonTaskProxyless",
@@ -50,6 +50,36 @@ func TestMapTaskType(t *testing.T) {
 			wantType: "RecaptchaV2Task",
 			wantKeys: []string{"proxyAddress", "proxyPort"},
 		},
+		{
+			name:     "image coordinates",
+			task:     &tasks.ImageCoordinatesTask{Body: "b", Instruction: "click the cars", MaxClicks: 3},
+			wantType: "CoordinatesTask",
+			wantKeys: []string{"body", "comment", "maxClicks"},
+		},
+		{
+			name:     "grid",
+			task:     &tasks.GridClassificationTask{Images: []string{"b"}, Question: "crosswalks", Rows: 4, Columns: 4},
+			wantType: "GridTask",
+			wantKeys: []string{"body", "comment", "rows", "columns"},
+		},
+		{
+			name:     "audio",
+			task:     &tasks.AudioTask{Body: "SUQz"},
+			wantType: "AudioTask",
+			wantKeys: []string{"body", "lang"},
+		},
+		{
+			name:     "rotate",
+			task:     &tasks.RotateTask{Body: "b", AngleStep: 15},
+			wantType: "RotateTask",
+			wantKeys: []string{"body", "angle"},
+		},
+		{
+			name:     "slider",
+			task:     &tasks.SliderTask{Background: "bg", Piece: "p"},
+			wantType: "CoordinatesTask",
+			wantKeys: []string{"body", "comment", "imgInstructions", "maxClicks"},
+		},
 		{
 			name:     "aws waf",
 			task:     &tasks.AWSWAFTask{WebsiteURL: "u", Key: "k"},
@@ -126,3 +156,24 @@ func TestMapTaskUnsupported(t *testing.T) {
 		t.Fatalf("errors.Is(%v, ErrUnsupportedTask) = false, want true", err)
 	}
 }
+
+func TestMapSliderComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction string
+		want        string
+	}{
+		{name: "default", want: sliderComment},
+		{name: "with instruction", instruction: "Ignore the shadow.", want: sliderComment + " Ignore the shadow."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapSlider(&tasks.SliderTask{Background: "bg", Piece: "p", Instruction: tt.instruction})
+
+			if got.Comment != tt.want {
+				t.Errorf("Comment = %q, want %q", got.Comment, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package unicap
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// Priority orders Solve calls waiting for an in-flight slot. Higher
+// priorities are admitted first; calls of equal priority are admitted in
+// arrival order.
+type Priority int
+
+const (
+	// PriorityLow suits background work such as crawls.
+	PriorityLow Priority = -1
+	// PriorityNormal is the default priority.
+	PriorityNormal Priority = 0
+	// PriorityHigh suits latency-sensitive work such as checkout flows.
+	PriorityHigh Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context that makes Solve queue at the given priority
+// when the client's in-flight limit is reached.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priorityFrom returns the priority carried by ctx, or PriorityNormal.
+func priorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+
+	return PriorityNormal
+}
+
+// slots is a counting semaphore whose waiters are admitted by priority.
+type slots struct {
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	waiting  waitQueue
+	seq      uint64
+}
+
+// waiter is a Solve call queued for a slot. ready is closed once the slot is
+// granted.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	index    int
+	granted  bool
+	ready    chan struct{}
+}
+
+func newSlots(limit int) *slots {
+	return &slots{limit: limit}
+}
+
+// acquire blocks until a slot is free or ctx is done.
+func (s *slots) acquire(ctx context.Context) error {
+	s.mu.Lock()
+
+	if s.inFlight < s.limit && s.waiting.Len() == 0 {
+		s.inFlight++
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	s.seq++
+	w := &waiter{priority: priorityFrom(ctx), seq: s.seq, ready: make(chan struct{})}
+	heap.Push(&s.waiting, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if w.granted {
+			// The slot was handed over as the context ended; pass it on.
+			s.releaseLocked()
+		} else {
+			heap.Remove(&s.waiting, w.index)
+		}
+
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, handing it to the highest-priority waiter if any.
+func (s *slots) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.releaseLocked()
+}
+
+func (s *slots) releaseLocked() {
+	if s.waiting.Len() == 0 {
+		s.inFlight--
+
+		return
+	}
+
+	w := heap.Pop(&s.waiting).(*waiter)
+	w.granted = true
+	close(w.ready)
+}
+
+func (s *slots) stats() (inFlight, queued int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.inFlight, s.waiting.Len()
+}
+
+// waitQueue is a heap of waiters ordered by priority, then arrival.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return w
+}
+
+// QueueDepth returns the number of Solve calls waiting for an in-flight slot.
+// It is always zero unless WithMaxInFlight is set.
+func (c *Client) QueueDepth() int {
+	if c.slots == nil {
+		return 0
+	}
+
+	_, queued := c.slots.stats()
+
+	return queued
+}
+
+// InFlight returns the number of Solve calls holding an in-flight slot. It is
+// always zero unless WithMaxInFlight is set.
+func (c *Client) InFlight() int {
+	if c.slots == nil {
+		return 0
+	}
+
+	inFlight, _ := c.slots.stats()
+
+	return inFlight
+}
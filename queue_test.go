@@ -0,0 +1,120 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitQueued blocks until s has n queued waiters.
+func waitQueued(t *testing.T, s *slots, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, queued := s.stats(); queued == n {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d queued waiters", n)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSlotsAdmitByPriority(t *testing.T) {
+	s := newSlots(1)
+
+	if err := s.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	admitted := make(chan Priority, 3)
+	enqueue := func(p Priority) {
+		go func() {
+			if err := s.acquire(WithPriority(context.Background(), p)); err != nil {
+				t.Errorf("acquire: %v", err)
+
+				return
+			}
+
+			admitted <- p
+		}()
+	}
+
+	enqueue(PriorityLow)
+	waitQueued(t, s, 1)
+	enqueue(PriorityNormal)
+	waitQueued(t, s, 2)
+	enqueue(PriorityHigh)
+	waitQueued(t, s, 3)
+
+	for _, want := range []Priority{PriorityHigh, PriorityNormal, PriorityLow} {
+		s.release()
+
+		if got := <-admitted; got != want {
+			t.Errorf("admitted priority %d, want %d", got, want)
+		}
+	}
+
+	s.release()
+
+	if inFlight, queued := s.stats(); inFlight != 0 || queued != 0 {
+		t.Errorf("stats() = %d in flight, %d queued, want 0, 0", inFlight, queued)
+	}
+}
+
+func TestSlotsAcquireCancelled(t *testing.T) {
+	s := newSlots(1)
+
+	if err := s.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	if err := s.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("errors.Is(%v, DeadlineExceeded) = false, want true", err)
+	}
+
+	if _, queued := s.stats(); queued != 0 {
+		t.Errorf("%d waiters still queued after cancellation, want 0", queued)
+	}
+}
+
+func TestClientQueueDepth(t *testing.T) {
+	provider := &answerProvider{answer: "a", delay: 50 * time.Millisecond}
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())), WithMaxInFlight(1))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	done := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := client.Solve(context.Background(), textTask{})
+			done <- err
+		}()
+	}
+
+	waitQueued(t, client.slots, 1)
+
+	if got := client.InFlight(); got != 1 {
+		t.Errorf("InFlight() = %d, want 1", got)
+	}
+
+	if got := client.QueueDepth(); got != 1 {
+		t.Errorf("QueueDepth() = %d, want 1", got)
+	}
+
+	for range 2 {
+		if err := <-done; err != nil {
+			t.Errorf("Solve: %v", err)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package unicap
 
+import "time"
+
 // TaskResult represents the result of a captcha solving task.
 type TaskResult struct {
 	// Status indicates the current state of the task.
@@ -29,7 +31,9 @@ const (
 // Solution contains the captcha solution data. Which field is populated depends
 // on the captcha type: token-based captchas set Token, cookie-based challenges
 // (DataDome, Cloudflare) set Cookie, image captchas set Text, and coordinate
-// captchas set Coordinates.
+// captchas set Coordinates. The remaining fields describe how the solution was
+// produced; the provider-reported ones are zero when the provider does not
+// return them.
 type Solution struct {
 	// Token is the primary solution for token-based captchas.
 	Token string
@@ -46,6 +50,48 @@ type Solution struct {
 
 	// Extra holds the raw, provider-specific solution payload.
 	Extra map[string]any
+
+	// Provider is the name of the provider that solved the task. Behind a
+	// Delegator such as a failover or pool, it names the backend that owned
+	// the task.
+	Provider string
+
+	// TaskID is the provider task ID, as accepted by ReportIncorrect and
+	// ReportCorrect.
+	TaskID string
+
+	// CreatedAt is when the task was created, as reported by the provider or
+	// otherwise observed by the client.
+	CreatedAt time.Time
+
+	// SolvedAt is when the task was solved, as reported by the provider or
+	// otherwise observed by the client.
+	SolvedAt time.Time
+
+	// ExpiresAt is when the solution stops being accepted by the target site,
+	// computed from SolvedAt and TokenTTL. It is zero when the lifetime is
+	// unknown.
+	ExpiresAt time.Time
+
+	// Polls is the number of result checks made before the solution was
+	// ready.
+	Polls int
+
+	// Cost is the price charged for the task in the provider's billing
+	// currency.
+	Cost float64
+
+	// SolverIP is the IP address of the worker that solved the task.
+	SolverIP string
+
+	// SolveCount is the number of workers that attempted the task.
+	SolveCount int
+}
+
+// Expired reports whether the solution is past ExpiresAt. A solution with an
+// unknown lifetime never expires.
+func (s *Solution) Expired() bool {
+	return !s.ExpiresAt.IsZero() && !time.Now().Before(s.ExpiresAt)
 }
 
 // Coordinate represents a point selection within an image.
@@ -0,0 +1,220 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientSolveMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		task        Task
+		solution    Solution
+		wantCreated time.Time
+		wantSolved  time.Time
+		wantTTL     time.Duration
+	}{
+		{
+			name:    "observed timings",
+			task:    tokenTask{},
+			wantTTL: 280 * time.Second,
+		},
+		{
+			name: "provider timings",
+			task: tokenTask{},
+			solution: Solution{
+				CreatedAt: time.Unix(1700000000, 0),
+				SolvedAt:  time.Unix(1700000007, 0),
+			},
+			wantCreated: time.Unix(1700000000, 0),
+			wantSolved:  time.Unix(1700000007, 0),
+			wantTTL:     280 * time.Second,
+		},
+		{
+			name: "unknown lifetime",
+			task: textTask{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{steps: []step{
+				{result: processing()},
+				{result: processing()},
+				{result: &TaskResult{Status: TaskStatusReady, Solution: tt.solution}},
+			}}
+
+			client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			start := time.Now()
+
+			solution, err := client.Solve(t.Context(), tt.task)
+			if err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+
+			if solution.Provider != "fake" {
+				t.Errorf("Provider = %q, want fake", solution.Provider)
+			}
+			if solution.TaskID != "task-1" {
+				t.Errorf("TaskID = %q, want task-1", solution.TaskID)
+			}
+			if solution.Polls != 3 {
+				t.Errorf("Polls = %d, want 3", solution.Polls)
+			}
+
+			if tt.wantCreated.IsZero() {
+				if solution.CreatedAt.Before(start) || solution.SolvedAt.Before(solution.CreatedAt) {
+					t.Errorf("CreatedAt = %v, SolvedAt = %v, want observed after %v", solution.CreatedAt, solution.SolvedAt, start)
+				}
+			} else {
+				if !solution.CreatedAt.Equal(tt.wantCreated) {
+					t.Errorf("CreatedAt = %v, want %v", solution.CreatedAt, tt.wantCreated)
+				}
+				if !solution.SolvedAt.Equal(tt.wantSolved) {
+					t.Errorf("SolvedAt = %v, want %v", solution.SolvedAt, tt.wantSolved)
+				}
+			}
+
+			if tt.wantTTL == 0 {
+				if !solution.ExpiresAt.IsZero() {
+					t.Errorf("ExpiresAt = %v, want zero", solution.ExpiresAt)
+				}
+
+				return
+			}
+
+			if got := solution.ExpiresAt.Sub(solution.SolvedAt); got != tt.wantTTL {
+				t.Errorf("ExpiresAt - SolvedAt = %v, want %v", got, tt.wantTTL)
+			}
+		})
+	}
+}
+
+// delegatingProvider namespaces the task IDs of the provider it wraps, as the
+// composite providers do.
+type delegatingProvider struct {
+	backend Provider
+}
+
+func (d *delegatingProvider) CreateTask(ctx context.Context, task Task) (string, error) {
+	taskID, err := d.backend.CreateTask(ctx, task)
+
+	return "0:" + taskID, err
+}
+
+func (d *delegatingProvider) GetTaskResult(ctx context.Context, taskID string) (*TaskResult, error) {
+	return d.backend.GetTaskResult(ctx, strings.TrimPrefix(taskID, "0:"))
+}
+
+func (d *delegatingProvider) Delegate(taskID string) (Provider, string, bool) {
+	backendID, ok := strings.CutPrefix(taskID, "0:")
+
+	return d.backend, backendID, ok
+}
+
+func (d *delegatingProvider) Name() string {
+	return "composite"
+}
+
+func TestClientSolveMetadataDelegated(t *testing.T) {
+	provider := &delegatingProvider{backend: &fakeProvider{steps: []step{{result: ready()}}}}
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	solution, err := client.Solve(t.Context(), tokenTask{})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+
+	if solution.Provider != "fake" {
+		t.Errorf("Provider = %q, want fake", solution.Provider)
+	}
+	if solution.TaskID != "0:task-1" {
+		t.Errorf("TaskID = %q, want 0:task-1", solution.TaskID)
+	}
+}
+
+func TestSolutionExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{name: "unknown lifetime", want: false},
+		{name: "valid", expiresAt: time.Now().Add(time.Minute), want: false},
+		{name: "expired", expiresAt: time.Now().Add(-time.Second), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution := Solution{ExpiresAt: tt.expiresAt}
+
+			if got := solution.Expired(); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// shortAnswerTask accepts only answers of at most three characters.
+type shortAnswerTask struct{ textTask }
+
+func (shortAnswerTask) ValidateSolution(s *Solution) error {
+	if len(s.Text) > 3 {
+		return ErrInvalidSolution
+	}
+
+	return nil
+}
+
+func TestClientSolveValidatesSolution(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantErrIs error
+	}{
+		{name: "accepted", text: "abc"},
+		{name: "rejected", text: "abcd", wantErrIs: ErrInvalidSolution},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{steps: []step{
+				{result: &TaskResult{Status: TaskStatusReady, Solution: Solution{Text: tt.text}}},
+			}}
+
+			client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			solution, err := client.Solve(t.Context(), shortAnswerTask{})
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+
+			if solution.Text != tt.text {
+				t.Errorf("Text = %q, want %q", solution.Text, tt.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package unicap
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBatchSize is the number of task IDs per batched result request
+	// when SchedulerConfig.BatchSize is unset.
+	defaultBatchSize = 100
+
+	// batchRequestTimeout bounds a batched result request, which serves many
+	// tasks and so cannot borrow any one caller's context.
+	batchRequestTimeout = 30 * time.Second
+)
+
+// SchedulerConfig defines how a shared poll scheduler spaces result checks.
+type SchedulerConfig struct {
+	// Rate is the maximum number of result requests per second across all
+	// tasks. Zero means unlimited.
+	Rate float64
+
+	// BatchSize caps the task IDs checked per request when the provider
+	// implements BatchProvider. Defaults to 100.
+	BatchSize int
+}
+
+// WithScheduler makes the poller check all of its outstanding tasks from a
+// single shared scheduler instead of running a timer loop per task. The
+// scheduler keeps tasks in a heap ordered by next check time, spaces result
+// requests to at most config.Rate per second, and, when the provider
+// implements BatchProvider, checks tasks that fall due together in one
+// request. Backoff per task still follows the poller's configuration and
+// profiles.
+func WithScheduler(config SchedulerConfig) PollerOption {
+	return func(p *Poller) {
+		if config.BatchSize <= 0 {
+			config.BatchSize = defaultBatchSize
+		}
+
+		var spacing time.Duration
+		if config.Rate > 0 {
+			spacing = time.Duration(float64(time.Second) / config.Rate)
+		}
+
+		p.scheduler = &scheduler{
+			poller:  p,
+			batch:   config.BatchSize,
+			spacing: spacing,
+			wake:    make(chan struct{}, 1),
+		}
+	}
+}
+
+// scheduler polls every outstanding task of a Poller from one goroutine,
+// which runs only while tasks are outstanding.
+type scheduler struct {
+	poller  *Poller
+	batch   int
+	spacing time.Duration
+	wake    chan struct{}
+
+	mu      sync.Mutex
+	queue   checkQueue
+	next    time.Time
+	running bool
+}
+
+// check is one outstanding task in the scheduler.
+type check struct {
+	ctx       context.Context
+	taskID    string
+	batchable bool
+	state     pollState
+	due       time.Time
+	index     int
+	cancelled bool
+	done      chan checkOutcome
+}
+
+// checkOutcome is the terminal result of a scheduled task.
+type checkOutcome struct {
+	result *TaskResult
+	err    error
+}
+
+// poll registers the task with its initial poll state and blocks until it
+// reaches a terminal state or ctx is done.
+func (s *scheduler) poll(ctx context.Context, taskID string, state pollState) (*TaskResult, error) {
+	c := &check{
+		ctx:    ctx,
+		taskID: taskID,
+		state:  state,
+		due:    time.Now().Add(state.next),
+		index:  -1,
+		done:   make(chan checkOutcome, 1),
+	}
+
+	if bp, ok := s.poller.provider.(BatchProvider); ok {
+		c.batchable = bp.Batchable(taskID)
+	}
+
+	s.mu.Lock()
+	s.pushLocked(c)
+	s.mu.Unlock()
+
+	select {
+	case out := <-c.done:
+		return out.result, out.err
+	case <-ctx.Done():
+		s.mu.Lock()
+		c.cancelled = true
+		if c.index >= 0 {
+			heap.Remove(&s.queue, c.index)
+		}
+		s.mu.Unlock()
+
+		return nil, pollError(ctx, taskID)
+	}
+}
+
+// pushLocked queues a check, starting the run loop if it is idle.
+func (s *scheduler) pushLocked(c *check) {
+	heap.Push(&s.queue, c)
+
+	if !s.running {
+		s.running = true
+		go s.run()
+
+		return
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run issues result requests as checks fall due, exiting once no check is
+// queued. Checks being fetched are not queued; they restart the loop when
+// they are requeued.
+func (s *scheduler) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+
+		if s.queue.Len() == 0 {
+			s.running = false
+			s.mu.Unlock()
+
+			return
+		}
+
+		now := time.Now()
+		at := s.queue[0].due
+		if s.next.After(at) {
+			at = s.next
+		}
+
+		if wait := at.Sub(now); wait > 0 {
+			s.mu.Unlock()
+
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-s.wake:
+				timer.Stop()
+			}
+
+			continue
+		}
+
+		checks := s.takeLocked(now)
+		s.next = now.Add(s.spacing)
+		s.mu.Unlock()
+
+		go s.fetch(checks)
+	}
+}
+
+// takeLocked pops the next due check. If it is batchable, it also pops other
+// batchable checks due before the next request slot, up to the batch size.
+func (s *scheduler) takeLocked(now time.Time) []*check {
+	head := heap.Pop(&s.queue).(*check)
+	if !head.batchable {
+		return []*check{head}
+	}
+
+	checks := []*check{head}
+	horizon := now.Add(s.spacing)
+
+	var skipped []*check
+	for s.queue.Len() > 0 && len(checks) < s.batch && !s.queue[0].due.After(horizon) {
+		c := heap.Pop(&s.queue).(*check)
+		if c.batchable {
+			checks = append(checks, c)
+		} else {
+			skipped = append(skipped, c)
+		}
+	}
+
+	for _, c := range skipped {
+		heap.Push(&s.queue, c)
+	}
+
+	return checks
+}
+
+// fetch retrieves the results of checks and settles or requeues each one.
+func (s *scheduler) fetch(checks []*check) {
+	if len(checks) == 1 {
+		c := checks[0]
+		result, err := s.poller.provider.GetTaskResult(c.ctx, c.taskID)
+		s.settle(c, result, err)
+
+		return
+	}
+
+	taskIDs := make([]string, len(checks))
+	for i, c := range checks {
+		taskIDs[i] = c.taskID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchRequestTimeout)
+	defer cancel()
+
+	results, err := s.poller.provider.(BatchProvider).GetTaskResults(ctx, taskIDs)
+	if err == nil && len(results) != len(checks) {
+		err = fmt.Errorf("batch returned %d results for %d tasks", len(results), len(checks))
+	}
+
+	for i, c := range checks {
+		if err != nil {
+			s.settle(c, nil, err)
+		} else {
+			s.settle(c, results[i], nil)
+		}
+	}
+}
+
+// settle applies one result check to c, delivering a terminal outcome or
+// requeueing it for its next check.
+func (s *scheduler) settle(c *check, result *TaskResult, err error) {
+	if c.ctx.Err() != nil {
+		// The caller has given up; poll has already returned.
+		return
+	}
+
+	done, result, err := s.poller.observe(c.ctx, c.taskID, &c.state, result, err)
+	if done {
+		c.done <- checkOutcome{result: result, err: err}
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.cancelled {
+		return
+	}
+
+	c.due = time.Now().Add(c.state.next)
+	s.pushLocked(c)
+}
+
+// checkQueue is a heap of checks ordered by due time.
+type checkQueue []*check
+
+func (q checkQueue) Len() int { return len(q) }
+
+func (q checkQueue) Less(i, j int) bool { return q[i].due.Before(q[j].due) }
+
+func (q checkQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *checkQueue) Push(x any) {
+	c := x.(*check)
+	c.index = len(*q)
+	*q = append(*q, c)
+}
+
+func (q *checkQueue) Pop() any {
+	old := *q
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	c.index = -1
+	*q = old[:n-1]
+
+	return c
+}
@@ -0,0 +1,205 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchingProvider reports each task ready after a fixed number of checks and
+// records the size of every result request.
+type batchingProvider struct {
+	checksToReady int
+	batchable     bool
+
+	mu       sync.Mutex
+	checks   map[string]int
+	requests []int
+	times    []time.Time
+}
+
+func newBatchingProvider(checksToReady int, batchable bool) *batchingProvider {
+	return &batchingProvider{
+		checksToReady: checksToReady,
+		batchable:     batchable,
+		checks:        make(map[string]int),
+	}
+}
+
+func (p *batchingProvider) CreateTask(context.Context, Task) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (p *batchingProvider) GetTaskResult(ctx context.Context, taskID string) (*TaskResult, error) {
+	results, err := p.GetTaskResults(ctx, []string{taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	return results[0], nil
+}
+
+func (p *batchingProvider) Batchable(string) bool {
+	return p.batchable
+}
+
+func (p *batchingProvider) GetTaskResults(_ context.Context, taskIDs []string) ([]*TaskResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, len(taskIDs))
+	p.times = append(p.times, time.Now())
+
+	results := make([]*TaskResult, len(taskIDs))
+	for i, id := range taskIDs {
+		p.checks[id]++
+		if p.checks[id] < p.checksToReady {
+			results[i] = processing()
+		} else {
+			results[i] = &TaskResult{Status: TaskStatusReady, Solution: Solution{Text: id}}
+		}
+	}
+
+	return results, nil
+}
+
+func (p *batchingProvider) Name() string {
+	return "batching"
+}
+
+// pollAll polls n tasks concurrently and fails the test on any error or
+// mismatched answer.
+func pollAll(t *testing.T, poller *Poller, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Go(func() {
+			taskID := strconv.Itoa(i)
+
+			result, err := poller.Poll(t.Context(), taskID)
+			if err != nil {
+				t.Errorf("Poll(%s): %v", taskID, err)
+
+				return
+			}
+
+			if result.Solution.Text != taskID {
+				t.Errorf("Poll(%s) Text = %q, want %q", taskID, result.Solution.Text, taskID)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestSchedulerBatches(t *testing.T) {
+	const tasks = 50
+
+	provider := newBatchingProvider(3, true)
+
+	config := testConfig()
+	config.InitialInterval = 10 * time.Millisecond
+	config.MaxInterval = 10 * time.Millisecond
+
+	poller := NewPoller(provider, config, WithScheduler(SchedulerConfig{Rate: 100, BatchSize: 20}))
+	pollAll(t, poller, tasks)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	total := 0
+	for _, size := range provider.requests {
+		if size > 20 {
+			t.Errorf("request checked %d tasks, want at most 20", size)
+		}
+
+		total += size
+	}
+
+	if total != tasks*3 {
+		t.Errorf("checked %d times, want %d", total, tasks*3)
+	}
+
+	if len(provider.requests) >= total {
+		t.Errorf("made %d requests for %d checks, want batching", len(provider.requests), total)
+	}
+}
+
+func TestSchedulerSpacesRequests(t *testing.T) {
+	provider := newBatchingProvider(2, false)
+
+	poller := NewPoller(provider, testConfig(), WithScheduler(SchedulerConfig{Rate: 200}))
+	pollAll(t, poller, 5)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if len(provider.requests) != 10 {
+		t.Fatalf("made %d requests, want 10", len(provider.requests))
+	}
+
+	for i := 1; i < len(provider.times); i++ {
+		// Allow for timer slack below the 5ms spacing.
+		if gap := provider.times[i].Sub(provider.times[i-1]); gap < 4*time.Millisecond {
+			t.Errorf("request %d followed the previous after %v, want at least 5ms", i, gap)
+		}
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	provider := newBatchingProvider(1000, true)
+	poller := NewPoller(provider, testConfig(), WithScheduler(SchedulerConfig{}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	errs := make(chan error, 3)
+	for i := range 3 {
+		go func() {
+			_, err := poller.Poll(ctx, fmt.Sprint(i))
+			errs <- err
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	for range 3 {
+		if err := <-errs; !errors.Is(err, context.Canceled) {
+			t.Errorf("Poll() error = %v, want context.Canceled", err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		poller.scheduler.mu.Lock()
+		idle := poller.scheduler.queue.Len() == 0 && !poller.scheduler.running
+		poller.scheduler.mu.Unlock()
+
+		if idle {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("scheduler still running after all polls were cancelled")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	provider := newBatchingProvider(1000, false)
+
+	config := testConfig()
+	config.Timeout = 15 * time.Millisecond
+
+	poller := NewPoller(provider, config, WithScheduler(SchedulerConfig{}))
+
+	if _, err := poller.Poll(t.Context(), "task-1"); !errors.Is(err, ErrTimeout) {
+		t.Errorf("errors.Is(%v, ErrTimeout) = false, want true", err)
+	}
+}
@@ -0,0 +1,127 @@
+package unicap
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// defaultSolveAllConcurrency is the number of concurrent solves SolveAll runs
+// when SolveAllOptions.Concurrency is unset.
+const defaultSolveAllConcurrency = 10
+
+// SolveAllOptions configures SolveAll.
+type SolveAllOptions struct {
+	// Concurrency is the maximum number of tasks solved at once. Defaults
+	// to 10.
+	Concurrency int
+
+	// Ordered yields results in input order rather than completion order. A
+	// slow task then holds back later results, and no more than Concurrency
+	// tasks are started ahead of it.
+	Ordered bool
+}
+
+// Result is the outcome of one task solved by SolveAll.
+type Result struct {
+	// Index is the position of the task in the input sequence.
+	Index int
+
+	Task     Task
+	Solution *Solution
+}
+
+// solveAllOutcome carries a Result and its error from a worker to the
+// consumer.
+type solveAllOutcome struct {
+	result Result
+	err    error
+}
+
+// SolveAll solves every task in tasks with Solve and yields each result with
+// its error as the solve completes. Tasks are pulled from tasks only as
+// concurrency frees up, so the sequence may be arbitrarily long. Stopping the
+// iteration cancels outstanding solves and stops consuming tasks. When ctx
+// ends, tasks not yet started are skipped and in-flight solves yield the
+// context error.
+func (c *Client) SolveAll(ctx context.Context, tasks iter.Seq[Task], opts SolveAllOptions) iter.Seq2[Result, error] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultSolveAllConcurrency
+	}
+
+	return func(yield func(Result, error) bool) {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// A slot is held from the moment a task starts until its result is
+		// yielded, so at most concurrency outcomes are ever outstanding and
+		// sends to outcomes never block.
+		slots := make(chan struct{}, concurrency)
+		outcomes := make(chan solveAllOutcome, concurrency)
+
+		wg.Go(func() {
+			var workers sync.WaitGroup
+			defer close(outcomes)
+			defer workers.Wait()
+
+			index := 0
+			for task := range tasks {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				i := index
+				index++
+
+				workers.Go(func() {
+					solution, err := c.Solve(ctx, task)
+					outcomes <- solveAllOutcome{
+						result: Result{Index: i, Task: task, Solution: solution},
+						err:    err,
+					}
+				})
+			}
+		})
+
+		emit := func(out solveAllOutcome) bool {
+			<-slots
+
+			return yield(out.result, out.err)
+		}
+
+		next := 0
+		pending := make(map[int]solveAllOutcome)
+
+		for out := range outcomes {
+			if !opts.Ordered {
+				if !emit(out) {
+					return
+				}
+
+				continue
+			}
+
+			pending[out.result.Index] = out
+
+			for {
+				out, ok := pending[next]
+				if !ok {
+					break
+				}
+
+				delete(pending, next)
+				next++
+
+				if !emit(out) {
+					return
+				}
+			}
+		}
+	}
+}
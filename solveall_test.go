@@ -0,0 +1,185 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// indexTask is a task identified by its position in a batch.
+type indexTask struct{ n int }
+
+func (indexTask) Type() TaskType  { return TaskTypeImageToText }
+func (indexTask) Validate() error { return nil }
+
+// batchProvider answers each indexTask with its index after a delay that
+// shrinks as the index grows, so later tasks finish first. It records the
+// peak number of tasks in flight.
+type batchProvider struct {
+	size     int
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (p *batchProvider) CreateTask(_ context.Context, task Task) (string, error) {
+	n := p.inFlight.Add(1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	return strconv.Itoa(task.(indexTask).n), nil
+}
+
+func (p *batchProvider) GetTaskResult(ctx context.Context, taskID string) (*TaskResult, error) {
+	n, _ := strconv.Atoi(taskID)
+
+	select {
+	case <-time.After(time.Duration(p.size-n) * time.Millisecond):
+	case <-ctx.Done():
+		p.inFlight.Add(-1)
+
+		return nil, ctx.Err()
+	}
+
+	p.inFlight.Add(-1)
+
+	return &TaskResult{Status: TaskStatusReady, Solution: Solution{Text: taskID}}, nil
+}
+
+func (p *batchProvider) Name() string {
+	return "batch"
+}
+
+func batch(n int) iter.Seq[Task] {
+	return func(yield func(Task) bool) {
+		for i := range n {
+			if !yield(indexTask{n: i}) {
+				return
+			}
+		}
+	}
+}
+
+func TestSolveAll(t *testing.T) {
+	const size = 20
+
+	tests := []struct {
+		name        string
+		opts        SolveAllOptions
+		wantOrdered bool
+	}{
+		{name: "unordered", opts: SolveAllOptions{Concurrency: 4}},
+		{name: "ordered", opts: SolveAllOptions{Concurrency: 4, Ordered: true}, wantOrdered: true},
+		{name: "default concurrency", opts: SolveAllOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &batchProvider{size: size}
+
+			client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			var indexes []int
+			for result, err := range client.SolveAll(t.Context(), batch(size), tt.opts) {
+				if err != nil {
+					t.Fatalf("result %d: %v", result.Index, err)
+				}
+
+				if got, want := result.Solution.Text, strconv.Itoa(result.Index); got != want {
+					t.Errorf("result %d: Text = %q, want %q", result.Index, got, want)
+				}
+
+				if got := result.Task.(indexTask).n; got != result.Index {
+					t.Errorf("result %d: Task index = %d", result.Index, got)
+				}
+
+				indexes = append(indexes, result.Index)
+			}
+
+			if len(indexes) != size {
+				t.Fatalf("got %d results, want %d", len(indexes), size)
+			}
+
+			if got := slices.IsSorted(indexes); tt.wantOrdered && !got {
+				t.Errorf("indexes = %v, want input order", indexes)
+			}
+
+			slices.Sort(indexes)
+			for i, got := range indexes {
+				if got != i {
+					t.Fatalf("indexes = %v, want each of 0..%d once", indexes, size-1)
+				}
+			}
+
+			concurrency := tt.opts.Concurrency
+			if concurrency == 0 {
+				concurrency = defaultSolveAllConcurrency
+			}
+
+			if peak := provider.peak.Load(); int(peak) > concurrency {
+				t.Errorf("peak in flight = %d, want at most %d", peak, concurrency)
+			}
+		})
+	}
+}
+
+func TestSolveAllBreak(t *testing.T) {
+	provider := &batchProvider{size: 5}
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	pulled := 0
+	tasks := func(yield func(Task) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(indexTask{n: i % 5}) {
+				return
+			}
+		}
+	}
+
+	for _, err := range client.SolveAll(t.Context(), tasks, SolveAllOptions{Concurrency: 2}) {
+		if err != nil {
+			t.Fatalf("SolveAll: %v", err)
+		}
+
+		break
+	}
+
+	if pulled > 4 {
+		t.Errorf("pulled %d tasks after break, want at most 4", pulled)
+	}
+}
+
+func TestSolveAllTaskError(t *testing.T) {
+	client, err := New(&batchProvider{size: 1})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tasks := slices.Values([]Task{nil})
+
+	for result, err := range client.SolveAll(t.Context(), tasks, SolveAllOptions{}) {
+		if !errors.Is(err, ErrInvalidTask) {
+			t.Errorf("errors.Is(err, ErrInvalidTask) = false, want true (err = %v)", err)
+		}
+
+		if result.Index != 0 || result.Solution != nil {
+			t.Errorf("result = %+v, want index 0 without solution", result)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSolveAtQuantile is the quantile of observed solve times SolveAt
+	// budgets for when SolveAtOptions.Quantile is unset.
+	defaultSolveAtQuantile = 0.9
+
+	// defaultSolveAtLead is the lead time SolveAt uses before enough solve
+	// times have been observed.
+	defaultSolveAtLead = 45 * time.Second
+
+	// solveAtMargin covers task creation and result delivery, which observed
+	// solve times do not include.
+	solveAtMargin = 2 * time.Second
+)
+
+// SolveAtOptions configures SolveAt.
+type SolveAtOptions struct {
+	// Quantile of observed solve times to budget for, between 0 and 1.
+	// Defaults to 0.9.
+	Quantile float64
+
+	// Lead is how long before the target time solving starts while too few
+	// solve times have been observed. Defaults to 45 seconds.
+	Lead time.Duration
+
+	// TTL is how long a solved token stays usable. Defaults to TokenTTL for
+	// the task type.
+	TTL time.Duration
+
+	// Parallel is the number of solves raced to cover variance in solve
+	// times; the first solution wins. Defaults to 1.
+	Parallel int
+}
+
+// SolveAt solves a task so that the solution is ready just before target and
+// still valid at target. It waits until target minus the lead time, then
+// solves as Solve does and returns as soon as a solution is ready. The lead
+// time is the configured quantile of solve times observed for the client's
+// provider and the task type, plus a small margin; it is capped at the token
+// lifetime less that margin so a fast solve is still valid at target.
+//
+// New's default poller records solve times. A poller passed with WithPoller
+// must be built with WithSolveTimes or WithAdaptivePolling for SolveAt to
+// learn from them; otherwise it always uses opts.Lead.
+func (c *Client) SolveAt(ctx context.Context, task Task, target time.Time, opts SolveAtOptions) (*Solution, error) {
+	if task == nil {
+		return nil, fmt.Errorf("task is nil: %w", ErrInvalidTask)
+	}
+
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("validate task: %w", err)
+	}
+
+	lead := c.leadTime(task.Type(), opts)
+	start := target.Add(-lead)
+
+	c.logger.DebugContext(ctx, "scheduling solve",
+		slog.String("task_type", string(task.Type())),
+		slog.Time("target", target),
+		slog.Duration("lead", lead),
+	)
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if opts.Parallel <= 1 {
+		return c.Solve(ctx, task)
+	}
+
+	return c.solveRace(ctx, task, opts.Parallel)
+}
+
+// leadTime returns how long before the target time a solve should start.
+func (c *Client) leadTime(taskType TaskType, opts SolveAtOptions) time.Duration {
+	q := opts.Quantile
+	if q <= 0 || q > 1 {
+		q = defaultSolveAtQuantile
+	}
+
+	lead := opts.Lead
+	if lead <= 0 {
+		lead = defaultSolveAtLead
+	}
+
+	if c.poller.times != nil {
+		if d, ok := c.poller.times.Quantile(c.provider.Name(), taskType, q); ok {
+			lead = d + solveAtMargin
+		}
+	}
+
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl, _ = TokenTTL(taskType)
+	}
+
+	if ttl > 0 {
+		lead = min(lead, max(ttl-solveAtMargin, 0))
+	}
+
+	return lead
+}
+
+// solveRace runs n solves of task at once and returns the first solution,
+// abandoning the rest.
+func (c *Client) solveRace(ctx context.Context, task Task, n int) (*Solution, error) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		solution *Solution
+		err      error
+	}
+
+	outcomes := make(chan outcome, n)
+	for range n {
+		wg.Go(func() {
+			solution, err := c.Solve(ctx, task)
+			outcomes <- outcome{solution: solution, err: err}
+		})
+	}
+
+	var errs []error
+	for range n {
+		out := <-outcomes
+		if out.err == nil {
+			return out.solution, nil
+		}
+
+		errs = append(errs, out.err)
+	}
+
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,106 @@
+package unicap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientLeadTime(t *testing.T) {
+	times := NewSolveTimes(0)
+	for i := range 10 {
+		times.Record("token", TaskTypeTurnstile, time.Duration(i+1)*10*time.Second)
+	}
+
+	provider := &tokenProvider{}
+
+	tests := []struct {
+		name     string
+		times    *SolveTimes
+		taskType TaskType
+		opts     SolveAtOptions
+		want     time.Duration
+	}{
+		{
+			name:     "no history uses default lead",
+			taskType: TaskTypeTurnstile,
+			want:     defaultSolveAtLead,
+		},
+		{
+			name:     "no history uses configured lead",
+			taskType: TaskTypeTurnstile,
+			opts:     SolveAtOptions{Lead: 20 * time.Second},
+			want:     20 * time.Second,
+		},
+		{
+			name:     "history quantile plus margin",
+			times:    times,
+			taskType: TaskTypeTurnstile,
+			opts:     SolveAtOptions{Quantile: 0.5},
+			want:     50*time.Second + solveAtMargin,
+		},
+		{
+			name:     "capped at token lifetime",
+			times:    times,
+			taskType: TaskTypeTurnstile,
+			opts:     SolveAtOptions{TTL: 30 * time.Second},
+			want:     30*time.Second - solveAtMargin,
+		},
+		{
+			name:     "capped at default token lifetime",
+			taskType: TaskTypeReCaptchaV2,
+			opts:     SolveAtOptions{Lead: 5 * time.Minute},
+			want:     110*time.Second - solveAtMargin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(provider, WithPoller(NewPoller(provider, testConfig(), WithAdaptivePolling(tt.times))))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			if got := client.leadTime(tt.taskType, tt.opts); got != tt.want {
+				t.Errorf("leadTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientSolveAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		parallel int
+		want     int32
+	}{
+		{name: "single", parallel: 1, want: 1},
+		{name: "parallel", parallel: 2, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &tokenProvider{}
+			client := newPoolClient(t, provider)
+
+			lead := 30 * time.Millisecond
+			target := time.Now().Add(80 * time.Millisecond)
+
+			solution, err := client.SolveAt(t.Context(), tokenTask{}, target, SolveAtOptions{Lead: lead, Parallel: tt.parallel})
+			if err != nil {
+				t.Fatalf("SolveAt: %v", err)
+			}
+
+			if solution.Token == "" {
+				t.Error("Token is empty")
+			}
+
+			if early := time.Until(target.Add(-lead)); early > 0 {
+				t.Errorf("solved %v before the scheduled start", early)
+			}
+
+			if got := provider.created.Load(); got != tt.want {
+				t.Errorf("solves = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package unicap
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSolveTimesWindow is the number of recent solve times kept per
+	// provider and task type when NewSolveTimes is given no window.
+	defaultSolveTimesWindow = 200
+
+	// minSolveSamples is the number of solve times needed before quantiles
+	// are reported.
+	minSolveSamples = 10
+)
+
+// SolveTimes records how long tasks take to become ready, per provider and
+// task type, over a sliding window of recent solves. A Poller configured with
+// WithSolveTimes records into it; one configured with WithAdaptivePolling also
+// schedules checks from it. It is safe for concurrent use and may be shared by
+// several pollers.
+type SolveTimes struct {
+	window int
+
+	mu     sync.Mutex
+	series map[solveTimesKey]*solveSeries
+}
+
+type solveTimesKey struct {
+	provider string
+	taskType TaskType
+}
+
+// solveSeries is a ring of recent solve times with a lazily sorted copy.
+type solveSeries struct {
+	samples []time.Duration
+	next    int
+	sorted  []time.Duration
+}
+
+// NewSolveTimes creates a SolveTimes that keeps the most recent window solve
+// times per provider and task type. A non-positive window keeps 200.
+func NewSolveTimes(window int) *SolveTimes {
+	if window <= 0 {
+		window = defaultSolveTimesWindow
+	}
+
+	return &SolveTimes{
+		window: window,
+		series: make(map[solveTimesKey]*solveSeries),
+	}
+}
+
+// Record adds the time a task of the given type took to become ready with
+// provider.
+func (s *SolveTimes) Record(provider string, taskType TaskType, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := solveTimesKey{provider: provider, taskType: taskType}
+
+	series, ok := s.series[key]
+	if !ok {
+		series = &solveSeries{}
+		s.series[key] = series
+	}
+
+	if len(series.samples) < s.window {
+		series.samples = append(series.samples, d)
+	} else {
+		series.samples[series.next] = d
+		series.next = (series.next + 1) % s.window
+	}
+
+	series.sorted = nil
+}
+
+// Quantile returns the q-quantile, between 0 and 1, of recent solve times for
+// the provider and task type. It reports false until enough solves have been
+// recorded to be meaningful.
+func (s *SolveTimes) Quantile(provider string, taskType TaskType, q float64) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	series, ok := s.series[solveTimesKey{provider: provider, taskType: taskType}]
+	if !ok || len(series.samples) < minSolveSamples {
+		return 0, false
+	}
+
+	if series.sorted == nil {
+		series.sorted = slices.Sorted(slices.Values(series.samples))
+	}
+
+	rank := int(q*float64(len(series.sorted))+0.5) - 1
+
+	return series.sorted[min(max(rank, 0), len(series.sorted)-1)], true
+}
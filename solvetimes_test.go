@@ -0,0 +1,44 @@
+package unicap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolveTimesQuantile(t *testing.T) {
+	times := NewSolveTimes(20)
+
+	for i := range minSolveSamples - 1 {
+		times.Record("fake", TaskTypeText, time.Duration(i+1)*time.Second)
+	}
+
+	if _, ok := times.Quantile("fake", TaskTypeText, 0.5); ok {
+		t.Fatalf("Quantile() ok with %d samples, want false", minSolveSamples-1)
+	}
+
+	for i := minSolveSamples - 1; i < 40; i++ {
+		times.Record("fake", TaskTypeText, time.Duration(i+1)*time.Second)
+	}
+
+	// Only the 20 most recent samples, 21s through 40s, remain.
+	tests := []struct {
+		q    float64
+		want time.Duration
+	}{
+		{q: 0, want: 21 * time.Second},
+		{q: 0.25, want: 25 * time.Second},
+		{q: 0.5, want: 30 * time.Second},
+		{q: 1, want: 40 * time.Second},
+	}
+
+	for _, tt := range tests {
+		got, ok := times.Quantile("fake", TaskTypeText, tt.q)
+		if !ok || got != tt.want {
+			t.Errorf("Quantile(%v) = %v, %v, want %v, true", tt.q, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := times.Quantile("other", TaskTypeText, 0.5); ok {
+		t.Error("Quantile() ok for unrecorded provider, want false")
+	}
+}
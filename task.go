@@ -9,6 +9,13 @@ type Task interface {
 	Validate() error
 }
 
+// SolutionValidator is implemented by tasks that constrain their answers
+// beyond what providers enforce. Client.Solve rejects solutions that fail
+// ValidateSolution with an error wrapping ErrInvalidSolution.
+type SolutionValidator interface {
+	ValidateSolution(s *Solution) error
+}
+
 // TaskType identifies the kind of captcha.
 type TaskType string
 
@@ -37,6 +44,16 @@ const (
 	TaskTypeGeeTestV4 TaskType = "geetest_v4"
 	// TaskTypeImageToText identifies an image-to-text task.
 	TaskTypeImageToText TaskType = "image_to_text"
+	// TaskTypeImageCoordinates identifies a click-the-objects image task.
+	TaskTypeImageCoordinates TaskType = "image_coordinates"
+	// TaskTypeGridClassification identifies an image grid classification task.
+	TaskTypeGridClassification TaskType = "grid_classification"
+	// TaskTypeAudio identifies an audio captcha task.
+	TaskTypeAudio TaskType = "audio"
+	// TaskTypeRotate identifies a rotate-to-upright puzzle task.
+	TaskTypeRotate TaskType = "rotate"
+	// TaskTypeSlider identifies a slider puzzle task.
+	TaskTypeSlider TaskType = "slider"
 	// TaskTypeAWSWAF identifies an AWS WAF captcha task.
 	TaskTypeAWSWAF TaskType = "aws_waf"
 	// TaskTypeMTCaptcha identifies an MTCaptcha task.
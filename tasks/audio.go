@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aarock1234/unicap"
+)
+
+// MaxAudioSize is the largest audio file, in bytes, an AudioTask accepts.
+const MaxAudioSize = 1 << 20
+
+// AudioTask represents an audio captcha: the worker transcribes a spoken
+// challenge, returned in Solution.Text.
+type AudioTask struct {
+	// Body is the base64-encoded MP3 or WAV file, at most MaxAudioSize
+	// bytes before encoding.
+	Body string
+
+	// Language is the language spoken in the recording as an ISO 639-1 code,
+	// such as "en" or "de". Defaults to English.
+	Language string
+
+	// MinLength and MaxLength bound the length of the answer in characters.
+	// Zero means no bound. Solutions outside the bounds are rejected by
+	// Client.Solve.
+	MinLength int
+	MaxLength int
+}
+
+// Type returns the SDK task type identifier.
+func (t *AudioTask) Type() unicap.TaskType {
+	return unicap.TaskTypeAudio
+}
+
+// Validate ensures required fields are present and that Body holds an MP3 or
+// WAV file within MaxAudioSize.
+func (t *AudioTask) Validate() error {
+	if t.Body == "" {
+		return fmt.Errorf("body: %w", unicap.ErrInvalidTask)
+	}
+
+	// Reject oversized bodies before decoding them. DecodedLen counts up to two
+	// padding bytes.
+	if base64.StdEncoding.DecodedLen(len(t.Body)) > MaxAudioSize+2 {
+		return fmt.Errorf("body exceeds %d bytes: %w", MaxAudioSize, unicap.ErrInvalidTask)
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(t.Body)
+	if err != nil {
+		return fmt.Errorf("body is not base64: %w", unicap.ErrInvalidTask)
+	}
+
+	if len(audio) > MaxAudioSize {
+		return fmt.Errorf("body exceeds %d bytes: %w", MaxAudioSize, unicap.ErrInvalidTask)
+	}
+
+	if !isMP3(audio) && !isWAV(audio) {
+		return fmt.Errorf("body is not MP3 or WAV: %w", unicap.ErrInvalidTask)
+	}
+
+	if t.MinLength < 0 || t.MaxLength < 0 || (t.MaxLength > 0 && t.MinLength > t.MaxLength) {
+		return fmt.Errorf("answer length: %w", unicap.ErrInvalidTask)
+	}
+
+	return nil
+}
+
+// ValidateSolution rejects answers outside MinLength and MaxLength.
+func (t *AudioTask) ValidateSolution(s *unicap.Solution) error {
+	n := utf8.RuneCountInString(s.Text)
+
+	if n == 0 || n < t.MinLength || (t.MaxLength > 0 && n > t.MaxLength) {
+		return fmt.Errorf("answer length %d: %w", n, unicap.ErrInvalidSolution)
+	}
+
+	return nil
+}
+
+// isMP3 reports whether audio starts with an ID3 tag or an MPEG audio frame.
+func isMP3(audio []byte) bool {
+	if bytes.HasPrefix(audio, []byte("ID3")) {
+		return true
+	}
+
+	return len(audio) >= 2 && audio[0] == 0xFF && audio[1]&0xE0 == 0xE0
+}
+
+// isWAV reports whether audio starts with a RIFF WAVE header.
+func isWAV(audio []byte) bool {
+	return len(audio) >= 12 && bytes.Equal(audio[:4], []byte("RIFF")) && bytes.Equal(audio[8:12], []byte("WAVE"))
+}
@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/aarock1234/unicap"
+)
+
+func TestAudioTaskValidate(t *testing.T) {
+	mp3 := base64.StdEncoding.EncodeToString([]byte("ID3\x04\x00audio"))
+	frame := base64.StdEncoding.EncodeToString([]byte{0xFF, 0xFB, 0x90, 0x00})
+	wav := base64.StdEncoding.EncodeToString([]byte("RIFF\x24\x00\x00\x00WAVEfmt "))
+
+	tests := []struct {
+		name    string
+		task    AudioTask
+		wantErr bool
+	}{
+		{name: "mp3 with id3 tag", task: AudioTask{Body: mp3}},
+		{name: "mp3 frame", task: AudioTask{Body: frame, Language: "de"}},
+		{name: "wav", task: AudioTask{Body: wav, MinLength: 4, MaxLength: 8}},
+		{name: "missing body", task: AudioTask{}, wantErr: true},
+		{name: "not base64", task: AudioTask{Body: "not base64!"}, wantErr: true},
+		{
+			name:    "unknown format",
+			task:    AudioTask{Body: base64.StdEncoding.EncodeToString([]byte("OggS\x00\x02"))},
+			wantErr: true,
+		},
+		{
+			name:    "too large",
+			task:    AudioTask{Body: base64.StdEncoding.EncodeToString(append([]byte("ID3"), bytes.Repeat([]byte{0}, MaxAudioSize)...))},
+			wantErr: true,
+		},
+		{name: "inverted length bounds", task: AudioTask{Body: mp3, MinLength: 8, MaxLength: 4}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.task.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, unicap.ErrInvalidTask) {
+				t.Errorf("error = %v, want wrapped ErrInvalidTask", err)
+			}
+		})
+	}
+}
+
+func TestAudioTaskValidateSolution(t *testing.T) {
+	task := AudioTask{MinLength: 4, MaxLength: 6}
+
+	tests := []struct {
+		text    string
+		wantErr bool
+	}{
+		{text: "1234"},
+		{text: "123456"},
+		{text: "", wantErr: true},
+		{text: "123", wantErr: true},
+		{text: "1234567", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			err := task.ValidateSolution(&unicap.Solution{Text: tt.text})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSolution(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, unicap.ErrInvalidSolution) {
+				t.Errorf("error = %v, want wrapped ErrInvalidSolution", err)
+			}
+		})
+	}
+}
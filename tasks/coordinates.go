@@ -0,0 +1,47 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/aarock1234/unicap"
+)
+
+// ImageCoordinatesTask represents a click captcha: the worker selects the
+// objects in an image that match an instruction. The selected points are
+// returned in Solution.Coordinates, in image pixels from the top-left corner.
+type ImageCoordinatesTask struct {
+	// Body is the base64-encoded image.
+	Body string
+
+	// Instruction tells the worker what to click, e.g. "click all the cars".
+	Instruction string
+
+	// InstructionImage is an optional base64-encoded image showing the
+	// instruction, for captchas that render it as a picture.
+	InstructionImage string
+
+	// MaxClicks caps the number of points selected. Zero means no cap.
+	MaxClicks int
+}
+
+// Type returns the SDK task type identifier.
+func (t *ImageCoordinatesTask) Type() unicap.TaskType {
+	return unicap.TaskTypeImageCoordinates
+}
+
+// Validate ensures required fields are present.
+func (t *ImageCoordinatesTask) Validate() error {
+	if t.Body == "" {
+		return fmt.Errorf("body: %w", unicap.ErrInvalidTask)
+	}
+
+	if t.Instruction == "" && t.InstructionImage == "" {
+		return fmt.Errorf("instruction: %w", unicap.ErrInvalidTask)
+	}
+
+	if t.MaxClicks < 0 {
+		return fmt.Errorf("max_clicks: %w", unicap.ErrInvalidTask)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/aarock1234/unicap"
+)
+
+// GridKind identifies the captcha family a grid challenge comes from. Some
+// providers run a separate classifier per family.
+type GridKind string
+
+const (
+	// GridKindReCaptcha is a reCAPTCHA v2 image grid.
+	GridKindReCaptcha GridKind = "recaptcha"
+	// GridKindHCaptcha is an hCaptcha image selection challenge.
+	GridKindHCaptcha GridKind = "hcaptcha"
+	// GridKindAWSWAF is an AWS WAF image grid.
+	GridKindAWSWAF GridKind = "aws_waf"
+)
+
+// GridClassificationTask represents the classification step of an image grid
+// challenge: the worker selects the tiles that match the question. Decode the
+// solution into a GridSolution for the selected tile indexes.
+type GridClassificationTask struct {
+	// Images are the base64-encoded challenge images: one image of the whole
+	// grid, or one image per tile.
+	Images []string
+
+	// Question is the instruction or object label, e.g. "Select all images
+	// with crosswalks" or a provider label such as "/m/014xcs".
+	Question string
+
+	// Rows and Columns describe the grid when Images holds a single image.
+	// Zero leaves the layout to the provider.
+	Rows    int
+	Columns int
+
+	// Kind selects the classifier on providers that run one per captcha
+	// family. It is required by CapSolver.
+	Kind GridKind
+}
+
+// Type returns the SDK task type identifier.
+func (t *GridClassificationTask) Type() unicap.TaskType {
+	return unicap.TaskTypeGridClassification
+}
+
+// Validate ensures required fields are present.
+func (t *GridClassificationTask) Validate() error {
+	if len(t.Images) == 0 {
+		return fmt.Errorf("images: %w", unicap.ErrInvalidTask)
+	}
+
+	for i, image := range t.Images {
+		if image == "" {
+			return fmt.Errorf("images[%d]: %w", i, unicap.ErrInvalidTask)
+		}
+	}
+
+	if t.Question == "" {
+		return fmt.Errorf("question: %w", unicap.ErrInvalidTask)
+	}
+
+	if t.Rows < 0 || t.Columns < 0 {
+		return fmt.Errorf("rows and columns: %w", unicap.ErrInvalidTask)
+	}
+
+	return nil
+}
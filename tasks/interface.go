@@ -16,6 +16,11 @@ var (
 	_ unicap.Task = (*GeeTestTask)(nil)
 	_ unicap.Task = (*GeeTestV4Task)(nil)
 	_ unicap.Task = (*ImageToTextTask)(nil)
+	_ unicap.Task = (*ImageCoordinatesTask)(nil)
+	_ unicap.Task = (*GridClassificationTask)(nil)
+	_ unicap.Task = (*AudioTask)(nil)
+	_ unicap.Task = (*RotateTask)(nil)
+	_ unicap.Task = (*SliderTask)(nil)
 	_ unicap.Task = (*AWSWAFTask)(nil)
 	_ unicap.Task = (*MTCaptchaTask)(nil)
 	_ unicap.Task = (*FriendlyCaptchaTask)(nil)
@@ -26,3 +31,21 @@ var (
 	_ unicap.Task = (*AltchaTask)(nil)
 	_ unicap.Task = (*RawTask)(nil)
 )
+
+// Compile-time assertions that every typed solution implements
+// unicap.SolutionDecoder.
+var (
+	_ unicap.SolutionDecoder = (*ReCaptchaSolution)(nil)
+	_ unicap.SolutionDecoder = (*GeeTestSolution)(nil)
+	_ unicap.SolutionDecoder = (*GeeTestV4Solution)(nil)
+	_ unicap.SolutionDecoder = (*AWSWAFSolution)(nil)
+	_ unicap.SolutionDecoder = (*GridSolution)(nil)
+	_ unicap.SolutionDecoder = (*RotateSolution)(nil)
+	_ unicap.SolutionDecoder = (*SliderSolution)(nil)
+)
+
+// Compile-time assertions that tasks with answer constraints implement
+// unicap.SolutionValidator.
+var (
+	_ unicap.SolutionValidator = (*AudioTask)(nil)
+)
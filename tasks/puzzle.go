@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/aarock1234/unicap"
+)
+
+// RotateTask represents a rotate puzzle: the worker finds the rotation that
+// brings an image upright. Decode the solution into a RotateSolution.
+type RotateTask struct {
+	// Body is the base64-encoded image to rotate.
+	Body string
+
+	// Background is an optional base64-encoded outer image for puzzles that
+	// rotate an inner disc against a fixed background.
+	Background string
+
+	// AngleStep is the rotation step of the puzzle control in degrees. Zero
+	// leaves it to the provider, which defaults to one degree.
+	AngleStep int
+
+	// Instruction is an optional hint for the worker.
+	Instruction string
+}
+
+// Type returns the SDK task type identifier.
+func (t *RotateTask) Type() unicap.TaskType {
+	return unicap.TaskTypeRotate
+}
+
+// Validate ensures required fields are present.
+func (t *RotateTask) Validate() error {
+	if t.Body == "" {
+		return fmt.Errorf("body: %w", unicap.ErrInvalidTask)
+	}
+
+	if t.AngleStep < 0 || t.AngleStep >= 360 {
+		return fmt.Errorf("angle_step: %w", unicap.ErrInvalidTask)
+	}
+
+	return nil
+}
+
+// SliderTask represents a slider puzzle: the worker finds where a piece fits
+// into the gap in a background image. Decode the solution into a
+// SliderSolution.
+type SliderTask struct {
+	// Background is the base64-encoded image with the gap.
+	Background string
+
+	// Piece is the base64-encoded piece to slide into the gap.
+	Piece string
+
+	// Instruction is an optional hint for the worker. 2Captcha appends it to
+	// its own instruction to click the gap's left edge.
+	Instruction string
+}
+
+// Type returns the SDK task type identifier.
+func (t *SliderTask) Type() unicap.TaskType {
+	return unicap.TaskTypeSlider
+}
+
+// Validate ensures required fields are present.
+func (t *SliderTask) Validate() error {
+	if t.Background == "" {
+		return fmt.Errorf("background: %w", unicap.ErrInvalidTask)
+	}
+
+	if t.Piece == "" {
+		return fmt.Errorf("piece: %w", unicap.ErrInvalidTask)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/aarock1234/unicap"
+)
+
+// ReCaptchaSolution is the typed solution of the reCAPTCHA tasks.
+type ReCaptchaSolution struct {
+	// Token is the g-recaptcha-response value.
+	Token string
+
+	// UserAgent is the user agent the token was solved with, when the
+	// provider reports it. Submit the token with the same user agent.
+	UserAgent string
+}
+
+// DecodeSolution implements unicap.SolutionDecoder.
+func (r *ReCaptchaSolution) DecodeSolution(s *unicap.Solution) error {
+	if s.Token == "" {
+		return fmt.Errorf("token: %w", unicap.ErrInvalidSolution)
+	}
+
+	r.Token = s.Token
+	r.UserAgent = extraString(s.Extra, "userAgent", "useragent", "user_agent")
+
+	return nil
+}
+
+// GeeTestSolution is the typed solution of a GeeTestTask (GeeTest v3).
+type GeeTestSolution struct {
+	Challenge string
+	Validate  string
+	SecCode   string
+}
+
+// DecodeSolution implements unicap.SolutionDecoder. Providers that omit the
+// seccode get the value GeeTest derives from validate.
+func (g *GeeTestSolution) DecodeSolution(s *unicap.Solution) error {
+	g.Challenge = extraString(s.Extra, "challenge", "geetest_challenge")
+	g.Validate = extraString(s.Extra, "validate", "geetest_validate")
+	g.SecCode = extraString(s.Extra, "seccode", "geetest_seccode")
+
+	if g.Challenge == "" {
+		return fmt.Errorf("challenge: %w", unicap.ErrInvalidSolution)
+	}
+
+	if g.Validate == "" {
+		return fmt.Errorf("validate: %w", unicap.ErrInvalidSolution)
+	}
+
+	if g.SecCode == "" {
+		g.SecCode = g.Validate + "|jordan"
+	}
+
+	return nil
+}
+
+// GeeTestV4Solution is the typed solution of a GeeTestV4Task.
+type GeeTestV4Solution struct {
+	CaptchaID     string
+	LotNumber     string
+	PassToken     string
+	GenTime       string
+	CaptchaOutput string
+}
+
+// DecodeSolution implements unicap.SolutionDecoder.
+func (g *GeeTestV4Solution) DecodeSolution(s *unicap.Solution) error {
+	g.CaptchaID = extraString(s.Extra, "captcha_id")
+	g.LotNumber = extraString(s.Extra, "lot_number")
+	g.PassToken = extraString(s.Extra, "pass_token")
+	g.GenTime = extraString(s.Extra, "gen_time")
+	g.CaptchaOutput = extraString(s.Extra, "captcha_output")
+
+	if g.LotNumber == "" {
+		return fmt.Errorf("lot_number: %w", unicap.ErrInvalidSolution)
+	}
+
+	if g.PassToken == "" {
+		return fmt.Errorf("pass_token: %w", unicap.ErrInvalidSolution)
+	}
+
+	if g.CaptchaOutput == "" {
+		return fmt.Errorf("captcha_output: %w", unicap.ErrInvalidSolution)
+	}
+
+	return nil
+}
+
+// AWSWAFSolution is the typed solution of an AWSWAFTask. CapSolver returns
+// the aws-waf-token cookie; 2Captcha returns a captcha voucher to exchange for
+// it.
+type AWSWAFSolution struct {
+	Cookie         string
+	CaptchaVoucher string
+	ExistingToken  string
+}
+
+// DecodeSolution implements unicap.SolutionDecoder.
+func (a *AWSWAFSolution) DecodeSolution(s *unicap.Solution) error {
+	a.Cookie = s.Cookie
+	a.CaptchaVoucher = extraString(s.Extra, "captcha_voucher")
+	a.ExistingToken = extraString(s.Extra, "existing_token")
+
+	if a.Cookie == "" && a.CaptchaVoucher == "" {
+		return fmt.Errorf("cookie: %w", unicap.ErrInvalidSolution)
+	}
+
+	return nil
+}
+
+// GridSolution is the typed solution of a GridClassificationTask.
+type GridSolution struct {
+	// Tiles are the zero-based indexes of the selected tiles, counted left to
+	// right and top to bottom, or the indexes of the selected images when the
+	// task has one image per tile.
+	Tiles []int
+}
+
+// DecodeSolution implements unicap.SolutionDecoder. It accepts CapSolver's
+// objects list of indexes or per-image booleans and 2Captcha's one-based click
+// list.
+func (g *GridSolution) DecodeSolution(s *unicap.Solution) error {
+	if click, ok := s.Extra["click"].([]any); ok {
+		tiles, err := tileIndexes(click)
+		if err != nil {
+			return err
+		}
+
+		for i := range tiles {
+			tiles[i]--
+		}
+
+		g.Tiles = tiles
+
+		return nil
+	}
+
+	objects, ok := s.Extra["objects"].([]any)
+	if !ok {
+		return fmt.Errorf("objects: %w", unicap.ErrInvalidSolution)
+	}
+
+	if len(objects) > 0 {
+		if _, ok := objects[0].(bool); ok {
+			g.Tiles = []int{}
+			for i, object := range objects {
+				if selected, _ := object.(bool); selected {
+					g.Tiles = append(g.Tiles, i)
+				}
+			}
+
+			return nil
+		}
+	}
+
+	tiles, err := tileIndexes(objects)
+	if err != nil {
+		return err
+	}
+
+	g.Tiles = tiles
+
+	return nil
+}
+
+// RotateSolution is the typed solution of a RotateTask.
+type RotateSolution struct {
+	// Angle is the rotation in degrees that brings the image upright.
+	Angle float64
+}
+
+// DecodeSolution implements unicap.SolutionDecoder. It accepts 2Captcha's
+// rotate and CapSolver's angle.
+func (r *RotateSolution) DecodeSolution(s *unicap.Solution) error {
+	for _, key := range []string{"rotate", "angle"} {
+		if angle, ok := s.Extra[key].(float64); ok {
+			r.Angle = angle
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("angle: %w", unicap.ErrInvalidSolution)
+}
+
+// SliderSolution is the typed solution of a SliderTask. Providers answer in
+// one of two ways, so exactly one field is set: Offset when the provider
+// reports how far to slide the piece, GapX when it reports where the gap is.
+// Distance reconciles the two.
+type SliderSolution struct {
+	// Offset is the horizontal distance in background pixels to slide the
+	// piece, as reported by CapSolver.
+	Offset int
+
+	// GapX is the x coordinate in background pixels of the gap's left edge,
+	// as reported by providers that solve sliders as a click on the gap.
+	GapX int
+}
+
+// DecodeSolution implements unicap.SolutionDecoder. It reads CapSolver's
+// distance into Offset and, for providers that solve sliders as a click on the
+// gap, the x coordinate of the first point into GapX.
+func (sl *SliderSolution) DecodeSolution(s *unicap.Solution) error {
+	if distance, ok := s.Extra["distance"].(float64); ok {
+		sl.Offset = int(math.Round(distance))
+
+		return nil
+	}
+
+	if len(s.Coordinates) > 0 {
+		sl.GapX = s.Coordinates[0].X
+
+		return nil
+	}
+
+	return fmt.Errorf("distance: %w", unicap.ErrInvalidSolution)
+}
+
+// Distance returns how far to slide a piece whose left edge starts at pieceX
+// in background pixels: Offset when the provider reported a distance,
+// otherwise GapX less pieceX.
+func (sl *SliderSolution) Distance(pieceX int) int {
+	if sl.GapX == 0 {
+		return sl.Offset
+	}
+
+	return sl.GapX - pieceX
+}
+
+// tileIndexes converts a JSON list of numbers to tile indexes.
+func tileIndexes(values []any) ([]int, error) {
+	tiles := make([]int, 0, len(values))
+
+	for _, v := range values {
+		n, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("tile %v: %w", v, unicap.ErrInvalidSolution)
+		}
+
+		tiles = append(tiles, int(n))
+	}
+
+	return tiles, nil
+}
+
+// extraString returns the first of keys present in a raw solution payload as
+// a string, formatting numbers without loss.
+func extraString(extra map[string]any, keys ...string) string {
+	for _, key := range keys {
+		switch v := extra[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,308 @@
+package tasks
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aarock1234/unicap"
+)
+
+func TestGeeTestSolutionDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		extra     map[string]any
+		want      GeeTestSolution
+		wantErrIs error
+	}{
+		{
+			name:  "full payload",
+			extra: map[string]any{"challenge": "c", "validate": "v", "seccode": "s"},
+			want:  GeeTestSolution{Challenge: "c", Validate: "v", SecCode: "s"},
+		},
+		{
+			name:  "prefixed keys",
+			extra: map[string]any{"geetest_challenge": "c", "geetest_validate": "v", "geetest_seccode": "s"},
+			want:  GeeTestSolution{Challenge: "c", Validate: "v", SecCode: "s"},
+		},
+		{
+			name:  "derived seccode",
+			extra: map[string]any{"challenge": "c", "validate": "v"},
+			want:  GeeTestSolution{Challenge: "c", Validate: "v", SecCode: "v|jordan"},
+		},
+		{
+			name:      "missing validate",
+			extra:     map[string]any{"challenge": "c"},
+			wantErrIs: unicap.ErrInvalidSolution,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution := unicap.Solution{Extra: tt.extra}
+
+			var got GeeTestSolution
+			err := solution.Decode(&got)
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeeTestV4SolutionDecode(t *testing.T) {
+	solution := unicap.Solution{Extra: map[string]any{
+		"captcha_id":     "id",
+		"lot_number":     "lot",
+		"pass_token":     "pass",
+		"gen_time":       float64(1680163289),
+		"captcha_output": "out",
+	}}
+
+	var got GeeTestV4Solution
+	if err := solution.Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	want := GeeTestV4Solution{CaptchaID: "id", LotNumber: "lot", PassToken: "pass", GenTime: "1680163289", CaptchaOutput: "out"}
+	if got != want {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAWSWAFSolutionDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		solution  unicap.Solution
+		want      AWSWAFSolution
+		wantErrIs error
+	}{
+		{
+			name:     "cookie",
+			solution: unicap.Solution{Cookie: "aws-waf-token=abc"},
+			want:     AWSWAFSolution{Cookie: "aws-waf-token=abc"},
+		},
+		{
+			name: "voucher",
+			solution: unicap.Solution{Extra: map[string]any{
+				"captcha_voucher": "voucher",
+				"existing_token":  "token",
+			}},
+			want: AWSWAFSolution{CaptchaVoucher: "voucher", ExistingToken: "token"},
+		},
+		{
+			name:      "empty",
+			wantErrIs: unicap.ErrInvalidSolution,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got AWSWAFSolution
+			err := tt.solution.Decode(&got)
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReCaptchaSolutionDecode(t *testing.T) {
+	solution := unicap.Solution{
+		Token: "token",
+		Extra: map[string]any{"gRecaptchaResponse": "token", "userAgent": "Mozilla/5.0"},
+	}
+
+	var got ReCaptchaSolution
+	if err := solution.Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	want := ReCaptchaSolution{Token: "token", UserAgent: "Mozilla/5.0"}
+	if got != want {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGridSolutionDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		extra     map[string]any
+		want      []int
+		wantErrIs error
+	}{
+		{
+			name:  "indexes",
+			extra: map[string]any{"objects": []any{float64(0), float64(4), float64(8)}},
+			want:  []int{0, 4, 8},
+		},
+		{
+			name:  "per-image booleans",
+			extra: map[string]any{"objects": []any{false, true, false, true}},
+			want:  []int{1, 3},
+		},
+		{
+			name:  "one-based clicks",
+			extra: map[string]any{"click": []any{float64(1), float64(5)}},
+			want:  []int{0, 4},
+		},
+		{
+			name:  "nothing selected",
+			extra: map[string]any{"objects": []any{}},
+			want:  []int{},
+		},
+		{
+			name:      "missing objects",
+			extra:     map[string]any{"text": "abc"},
+			wantErrIs: unicap.ErrInvalidSolution,
+		},
+		{
+			name:      "malformed index",
+			extra:     map[string]any{"objects": []any{"a"}},
+			wantErrIs: unicap.ErrInvalidSolution,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution := unicap.Solution{Extra: tt.extra}
+
+			var got GridSolution
+			err := solution.Decode(&got)
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			if !slices.Equal(got.Tiles, tt.want) {
+				t.Errorf("Tiles = %v, want %v", got.Tiles, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotateSolutionDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		extra     map[string]any
+		want      float64
+		wantErrIs error
+	}{
+		{name: "rotate", extra: map[string]any{"rotate": float64(135)}, want: 135},
+		{name: "angle", extra: map[string]any{"angle": float64(42.5)}, want: 42.5},
+		{name: "missing", extra: map[string]any{}, wantErrIs: unicap.ErrInvalidSolution},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution := unicap.Solution{Extra: tt.extra}
+
+			var got RotateSolution
+			err := solution.Decode(&got)
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			if got.Angle != tt.want {
+				t.Errorf("Angle = %v, want %v", got.Angle, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliderSolutionDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		solution   unicap.Solution
+		want       SliderSolution
+		wantTravel int
+		wantErrIs  error
+	}{
+		{
+			name:       "distance",
+			solution:   unicap.Solution{Extra: map[string]any{"distance": float64(213.4)}},
+			want:       SliderSolution{Offset: 213},
+			wantTravel: 213,
+		},
+		{
+			name:       "clicked gap",
+			solution:   unicap.Solution{Coordinates: []unicap.Coordinate{{X: 187, Y: 40}}},
+			want:       SliderSolution{GapX: 187},
+			wantTravel: 177,
+		},
+		{
+			name:      "missing",
+			wantErrIs: unicap.ErrInvalidSolution,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SliderSolution
+			err := tt.solution.Decode(&got)
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Decode = %+v, want %+v", got, tt.want)
+			}
+
+			if travel := got.Distance(10); travel != tt.wantTravel {
+				t.Errorf("Distance(10) = %d, want %d", travel, tt.wantTravel)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package unicap
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMinValidity is how much lifetime a pooled token must have left
+	// to be handed out when TokenPoolConfig.MinValidity is unset.
+	defaultMinValidity = 10 * time.Second
+
+	// poolRetryBase and poolRetryMax bound the backoff after failed refills.
+	poolRetryBase = time.Second
+	poolRetryMax  = time.Minute
+)
+
+// TokenPoolConfig defines how many tokens a TokenPool keeps and for how long.
+type TokenPoolConfig struct {
+	// Size is the number of tokens kept solved and ready. Defaults to 1.
+	Size int
+
+	// TTL is how long a solved token stays usable, counted from when the
+	// provider solved it. Defaults to TokenTTL for the task type and is
+	// required for types without a known lifetime.
+	TTL time.Duration
+
+	// MinValidity is the lifetime a token must have left to be handed out;
+	// older tokens are discarded and replaced. Defaults to 10 seconds.
+	MinValidity time.Duration
+
+	// MaxSolves caps the number of solves the pool pays for over its
+	// lifetime. Zero means unlimited.
+	MaxSolves int
+}
+
+// TokenPool keeps tokens for a task template solved ahead of time so that
+// Get returns without waiting for a solve. It refills in the background with
+// Client.Solve as tokens are taken or age out. It is safe for concurrent use.
+type TokenPool struct {
+	client *Client
+	task   Task
+	config TokenPoolConfig
+
+	// customTTL is set when config.TTL was given rather than defaulted, so
+	// it overrides the expiry the client derived for each solution.
+	customTTL bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	wake   chan struct{}
+
+	mu       sync.Mutex
+	tokens   []pooledToken
+	inFlight int
+	started  int
+	failures int
+	retryAt  time.Time
+	changed  chan struct{}
+	closed   bool
+}
+
+// pooledToken is a solved token and the time it stops being usable.
+type pooledToken struct {
+	solution  *Solution
+	expiresAt time.Time
+}
+
+// NewTokenPool creates a pool of solved tokens for task and starts filling it.
+// Close the pool to stop refilling.
+func NewTokenPool(client *Client, task Task, config TokenPoolConfig) (*TokenPool, error) {
+	if client == nil {
+		return nil, ErrNilProvider
+	}
+
+	if task == nil {
+		return nil, fmt.Errorf("task is nil: %w", ErrInvalidTask)
+	}
+
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("validate task: %w", err)
+	}
+
+	if config.Size <= 0 {
+		config.Size = 1
+	}
+	if config.MinValidity <= 0 {
+		config.MinValidity = defaultMinValidity
+	}
+
+	customTTL := config.TTL > 0
+	if !customTTL {
+		ttl, ok := TokenTTL(task.Type())
+		if !ok {
+			return nil, fmt.Errorf("no token lifetime known for %s, set TTL: %w", task.Type(), ErrUnsupportedTask)
+		}
+
+		config.TTL = ttl
+	}
+	if config.TTL <= config.MinValidity {
+		return nil, fmt.Errorf("TTL %v does not exceed MinValidity %v: %w", config.TTL, config.MinValidity, ErrInvalidTask)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &TokenPool{
+		client:    client,
+		task:      task,
+		config:    config,
+		customTTL: customTTL,
+		ctx:       ctx,
+		cancel:    cancel,
+		wake:      make(chan struct{}, 1),
+		changed:   make(chan struct{}),
+	}
+
+	p.wg.Go(p.run)
+
+	return p, nil
+}
+
+// Get returns a token with at least MinValidity of its lifetime left. It
+// returns immediately when the pool holds one and otherwise waits for the
+// next solve. It returns ErrBudgetExhausted once MaxSolves is spent and the
+// pool is empty, and ErrPoolClosed after Close.
+func (p *TokenPool) Get(ctx context.Context) (*Solution, error) {
+	for {
+		p.mu.Lock()
+
+		if p.closed {
+			p.mu.Unlock()
+
+			return nil, ErrPoolClosed
+		}
+
+		p.dropExpiredLocked(time.Now())
+
+		if len(p.tokens) > 0 {
+			token := p.tokens[0]
+			p.tokens = p.tokens[1:]
+			p.mu.Unlock()
+
+			p.signal()
+
+			return token.solution, nil
+		}
+
+		if p.spentLocked() && p.inFlight == 0 {
+			p.mu.Unlock()
+
+			return nil, ErrBudgetExhausted
+		}
+
+		changed := p.changed
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Len returns the number of usable tokens in the pool.
+func (p *TokenPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.dropExpiredLocked(time.Now())
+
+	return len(p.tokens)
+}
+
+// Close stops refilling, abandons in-flight solves, and discards pooled
+// tokens. Pending and later Get calls return ErrPoolClosed.
+func (p *TokenPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+
+		return
+	}
+
+	p.closed = true
+	p.tokens = nil
+	p.broadcastLocked()
+	p.mu.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+}
+
+// run starts solves whenever the pool is short of tokens and wakes when a
+// token expires or a failed refill may be retried.
+func (p *TokenPool) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		p.mu.Lock()
+
+		now := time.Now()
+		p.dropExpiredLocked(now)
+
+		for p.needsSolveLocked(now) {
+			p.inFlight++
+			p.started++
+			p.wg.Go(p.refill)
+		}
+
+		next := p.nextEventLocked()
+		p.mu.Unlock()
+
+		if !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-p.wake:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+	}
+}
+
+// refill solves one token and adds it to the pool.
+func (p *TokenPool) refill() {
+	solution, err := p.client.Solve(p.ctx, p.task)
+	now := time.Now()
+
+	p.mu.Lock()
+	p.inFlight--
+
+	if err != nil {
+		// Providers generally charge only for solved tasks.
+		p.started--
+		p.failures++
+		p.retryAt = now.Add(min(poolRetryBase<<min(p.failures-1, 6), poolRetryMax))
+
+		if p.ctx.Err() == nil {
+			p.client.logger.WarnContext(p.ctx, "token pool refill failed",
+				slog.String("task_type", string(p.task.Type())),
+				slog.Int("consecutive_failures", p.failures),
+				slog.Any("error", err),
+			)
+		}
+	} else if !p.closed {
+		p.failures = 0
+
+		// Count the lifetime from when the provider solved the token, which
+		// can be up to a poll interval before the client saw it.
+		if p.customTTL || solution.ExpiresAt.IsZero() {
+			solvedAt := solution.SolvedAt
+			if solvedAt.IsZero() {
+				solvedAt = now
+			}
+
+			solution.ExpiresAt = solvedAt.Add(p.config.TTL)
+		}
+
+		p.tokens = append(p.tokens, pooledToken{
+			solution:  solution,
+			expiresAt: solution.ExpiresAt,
+		})
+	}
+
+	p.broadcastLocked()
+	p.mu.Unlock()
+
+	p.signal()
+}
+
+// needsSolveLocked reports whether another solve should start now.
+func (p *TokenPool) needsSolveLocked(now time.Time) bool {
+	if p.closed || p.spentLocked() || now.Before(p.retryAt) {
+		return false
+	}
+
+	return len(p.tokens)+p.inFlight < p.config.Size
+}
+
+// spentLocked reports whether the solve budget is used up.
+func (p *TokenPool) spentLocked() bool {
+	return p.config.MaxSolves > 0 && p.started >= p.config.MaxSolves
+}
+
+// nextEventLocked returns when the pool next needs attention without being
+// woken: the earliest token expiry or refill retry, or the zero time.
+func (p *TokenPool) nextEventLocked() time.Time {
+	var next time.Time
+
+	if len(p.tokens) > 0 {
+		next = p.tokens[0].expiresAt.Add(-p.config.MinValidity)
+	}
+
+	if p.retryAt.After(time.Now()) && (next.IsZero() || p.retryAt.Before(next)) {
+		next = p.retryAt
+	}
+
+	return next
+}
+
+// dropExpiredLocked discards tokens with less than MinValidity left. Tokens
+// are kept oldest first.
+func (p *TokenPool) dropExpiredLocked(now time.Time) {
+	cutoff := now.Add(p.config.MinValidity)
+
+	i := 0
+	for i < len(p.tokens) && !p.tokens[i].expiresAt.After(cutoff) {
+		i++
+	}
+
+	if i > 0 {
+		p.tokens = p.tokens[i:]
+		p.signal()
+	}
+}
+
+// broadcastLocked wakes every Get waiting for the pool to change.
+func (p *TokenPool) broadcastLocked() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// signal wakes the refill loop.
+func (p *TokenPool) signal() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,207 @@
+package unicap
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenProvider issues a distinct token for every task after delay.
+type tokenProvider struct {
+	delay   time.Duration
+	created atomic.Int32
+}
+
+func (p *tokenProvider) CreateTask(context.Context, Task) (string, error) {
+	return strconv.Itoa(int(p.created.Add(1))), nil
+}
+
+func (p *tokenProvider) GetTaskResult(ctx context.Context, taskID string) (*TaskResult, error) {
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return &TaskResult{Status: TaskStatusReady, Solution: Solution{Token: "token-" + taskID}}, nil
+}
+
+func (p *tokenProvider) Name() string {
+	return "token"
+}
+
+func newPoolClient(t *testing.T, provider Provider) *Client {
+	t.Helper()
+
+	client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return client
+}
+
+// waitLen blocks until the pool holds n tokens.
+func waitLen(t *testing.T, pool *TokenPool, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for pool.Len() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("pool holds %d tokens, want %d", pool.Len(), n)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTokenPoolGet(t *testing.T) {
+	provider := &tokenProvider{delay: 20 * time.Millisecond}
+
+	pool, err := NewTokenPool(newPoolClient(t, provider), tokenTask{}, TokenPoolConfig{Size: 2})
+	if err != nil {
+		t.Fatalf("NewTokenPool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	waitLen(t, pool, 2)
+
+	start := time.Now()
+	seen := make(map[string]bool)
+
+	for range 2 {
+		solution, err := pool.Get(t.Context())
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if seen[solution.Token] {
+			t.Errorf("token %q handed out twice", solution.Token)
+		}
+		seen[solution.Token] = true
+	}
+
+	if elapsed := time.Since(start); elapsed >= provider.delay {
+		t.Errorf("Get from a full pool took %v, want less than a solve (%v)", elapsed, provider.delay)
+	}
+
+	waitLen(t, pool, 2)
+
+	if got := provider.created.Load(); got != 4 {
+		t.Errorf("solves = %d, want 4", got)
+	}
+}
+
+func TestTokenPoolExpiry(t *testing.T) {
+	provider := &tokenProvider{}
+
+	pool, err := NewTokenPool(newPoolClient(t, provider), tokenTask{}, TokenPoolConfig{
+		Size:        1,
+		TTL:         40 * time.Millisecond,
+		MinValidity: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenPool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	waitLen(t, pool, 1)
+	time.Sleep(100 * time.Millisecond)
+
+	if got := provider.created.Load(); got < 3 {
+		t.Errorf("solves = %d, want expired tokens replaced", got)
+	}
+}
+
+func TestTokenPoolExpiresFromSolveTime(t *testing.T) {
+	solvedAt := time.Now().Add(-5 * time.Second)
+	ttl, _ := TokenTTL(tokenTask{}.Type())
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want time.Time
+	}{
+		{name: "default lifetime", want: solvedAt.Add(ttl)},
+		{name: "configured lifetime", ttl: time.Minute, want: solvedAt.Add(time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{steps: []step{{result: &TaskResult{
+				Status:   TaskStatusReady,
+				Solution: Solution{Token: "solved", SolvedAt: solvedAt},
+			}}}}
+
+			pool, err := NewTokenPool(newPoolClient(t, provider), tokenTask{}, TokenPoolConfig{TTL: tt.ttl, MaxSolves: 1})
+			if err != nil {
+				t.Fatalf("NewTokenPool: %v", err)
+			}
+			t.Cleanup(pool.Close)
+
+			solution, err := pool.Get(t.Context())
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+
+			if !solution.ExpiresAt.Equal(tt.want) {
+				t.Errorf("ExpiresAt = %v, want %v", solution.ExpiresAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenPoolBudget(t *testing.T) {
+	provider := &tokenProvider{}
+
+	pool, err := NewTokenPool(newPoolClient(t, provider), tokenTask{}, TokenPoolConfig{Size: 1, MaxSolves: 2})
+	if err != nil {
+		t.Fatalf("NewTokenPool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	for range 2 {
+		if _, err := pool.Get(t.Context()); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+
+	if _, err := pool.Get(t.Context()); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("errors.Is(%v, ErrBudgetExhausted) = false, want true", err)
+	}
+
+	if got := provider.created.Load(); got != 2 {
+		t.Errorf("solves = %d, want 2", got)
+	}
+}
+
+func TestTokenPoolClose(t *testing.T) {
+	provider := &tokenProvider{delay: time.Second}
+
+	pool, err := NewTokenPool(newPoolClient(t, provider), tokenTask{}, TokenPoolConfig{})
+	if err != nil {
+		t.Fatalf("NewTokenPool: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := pool.Get(t.Context())
+		errs <- err
+	}()
+
+	pool.Close()
+
+	if err := <-errs; !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("errors.Is(%v, ErrPoolClosed) = false, want true", err)
+	}
+}
+
+func TestNewTokenPoolUnknownTTL(t *testing.T) {
+	_, err := NewTokenPool(newPoolClient(t, &tokenProvider{}), textTask{}, TokenPoolConfig{})
+	if !errors.Is(err, ErrUnsupportedTask) {
+		t.Errorf("errors.Is(%v, ErrUnsupportedTask) = false, want true", err)
+	}
+}
@@ -0,0 +1,56 @@
+package unicap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SolutionDecoder is implemented by typed solutions that decode themselves
+// from a Solution, reconciling the payload keys of different providers. The
+// typed solutions in package tasks implement it.
+type SolutionDecoder interface {
+	DecodeSolution(s *Solution) error
+}
+
+// Decode decodes the solution into v, which must be a non-nil pointer. When v
+// implements SolutionDecoder, its DecodeSolution method is used; otherwise the
+// raw provider payload in Extra is decoded into v as JSON.
+func (s *Solution) Decode(v any) error {
+	if d, ok := v.(SolutionDecoder); ok {
+		if err := d.DecodeSolution(s); err != nil {
+			return fmt.Errorf("decoding solution: %w", err)
+		}
+
+		return nil
+	}
+
+	data, err := json.Marshal(s.Extra)
+	if err != nil {
+		return fmt.Errorf("marshaling solution payload: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding solution: %w", err)
+	}
+
+	return nil
+}
+
+// SolveTyped solves task with client and decodes the solution into S, such as
+// tasks.GeeTestV4Solution. The untyped solution is returned alongside for its
+// metadata.
+func SolveTyped[S any](ctx context.Context, client *Client, task Task) (S, *Solution, error) {
+	var typed S
+
+	solution, err := client.Solve(ctx, task)
+	if err != nil {
+		return typed, nil, err
+	}
+
+	if err := solution.Decode(&typed); err != nil {
+		return typed, solution, err
+	}
+
+	return typed, solution, nil
+}
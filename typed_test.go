@@ -0,0 +1,93 @@
+package unicap
+
+import (
+	"errors"
+	"testing"
+)
+
+// labelSolution decodes through the JSON fallback of Solution.Decode.
+type labelSolution struct {
+	Label string `json:"label"`
+	Score int    `json:"score"`
+}
+
+func TestSolutionDecodeJSON(t *testing.T) {
+	solution := Solution{Extra: map[string]any{"label": "cat", "score": float64(3)}}
+
+	var got labelSolution
+	if err := solution.Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if want := (labelSolution{Label: "cat", Score: 3}); got != want {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+}
+
+// tokenSolution requires a token through SolutionDecoder.
+type tokenSolution struct {
+	Token string
+}
+
+func (s *tokenSolution) DecodeSolution(solution *Solution) error {
+	if solution.Token == "" {
+		return ErrInvalidSolution
+	}
+
+	s.Token = solution.Token
+
+	return nil
+}
+
+func TestSolveTyped(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    *TaskResult
+		want      string
+		wantErrIs error
+	}{
+		{
+			name:   "decoded",
+			result: ready(),
+			want:   "solved",
+		},
+		{
+			name:      "invalid payload",
+			result:    &TaskResult{Status: TaskStatusReady},
+			wantErrIs: ErrInvalidSolution,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{steps: []step{{result: tt.result}}}
+
+			client, err := New(provider, WithPoller(NewPoller(provider, testConfig())))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			got, solution, err := SolveTyped[tokenSolution](t.Context(), client, tokenTask{})
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("errors.Is(%v, %v) = false, want true", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SolveTyped: %v", err)
+			}
+
+			if got.Token != tt.want {
+				t.Errorf("Token = %q, want %q", got.Token, tt.want)
+			}
+
+			if solution.TaskID != "task-1" {
+				t.Errorf("TaskID = %q, want task-1", solution.TaskID)
+			}
+		})
+	}
+}